@@ -2,6 +2,10 @@
 package typeid_test
 
 import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/gofrs/uuid/v5"
@@ -25,6 +29,7 @@ var (
 	sinkBytes  []byte
 	sinkError  error
 	sinkUUID   uuid.UUID
+	sinkInt    int
 )
 
 // Test data patterns for varied input benchmarks
@@ -499,11 +504,169 @@ func BenchmarkTypicalUsage(b *testing.B) {
 	})
 }
 
-// TODO: Move test types to shared location
-type TestPrefix struct{}
+// BenchmarkTypedID measures the overhead of the prefix check in typed IDs
+func BenchmarkTypedID(b *testing.B) {
+	s := typeid.MustGenerate("test").String()
 
-func (TestPrefix) Prefix() string { return "test" }
+	b.Run("generate", func(b *testing.B) {
+		b.ReportAllocs()
+		var id TestID
+		var err error
+
+		for b.Loop() {
+			id, err = typeid.GenerateID[TestPrefix]()
+		}
+
+		sinkString = id.String()
+		sinkError = err
+	})
+
+	b.Run("parse", func(b *testing.B) {
+		b.ReportAllocs()
+		var id TestID
+		var err error
+
+		for b.Loop() {
+			id, err = typeid.ParseID[TestPrefix](s)
+		}
+
+		sinkString = id.String()
+		sinkError = err
+	})
+}
+
+// BenchmarkSort compares sorting TypeIDs with the comparators to sorting
+// their string forms
+func BenchmarkSort(b *testing.B) {
+	b.Run("compare", func(b *testing.B) {
+		b.ReportAllocs()
+		ids := make([]typeid.TypeID, len(testTypeIDs))
+
+		for b.Loop() {
+			copy(ids, testTypeIDs)
+			slices.SortFunc(ids, typeid.Compare)
+		}
+
+		sinkTypeID = ids[0]
+	})
+
+	b.Run("compare_uuid", func(b *testing.B) {
+		b.ReportAllocs()
+		ids := make([]typeid.TypeID, len(testTypeIDs))
+
+		for b.Loop() {
+			copy(ids, testTypeIDs)
+			slices.SortFunc(ids, typeid.CompareUUID)
+		}
+
+		sinkTypeID = ids[0]
+	})
+
+	// Sorting the string form, as callers had to before Compare existed
+	b.Run("strings", func(b *testing.B) {
+		b.ReportAllocs()
+		ids := make([]typeid.TypeID, len(testTypeIDs))
+
+		for b.Loop() {
+			copy(ids, testTypeIDs)
+			slices.SortFunc(ids, func(a, b typeid.TypeID) int {
+				return strings.Compare(a.String(), b.String())
+			})
+		}
+
+		sinkTypeID = ids[0]
+	})
+
+	b.Run("bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		ids := make([]typeid.TypeID, len(testTypeIDs))
+
+		for b.Loop() {
+			copy(ids, testTypeIDs)
+			slices.SortFunc(ids, func(a, b typeid.TypeID) int {
+				return bytes.Compare(a.Bytes(), b.Bytes())
+			})
+		}
+
+		sinkTypeID = ids[0]
+	})
+}
+
+// BenchmarkBinary measures binary encoding and decoding performance
+func BenchmarkBinary(b *testing.B) {
+	encoded := make([][]byte, len(testTypeIDs))
+	for i, tid := range testTypeIDs {
+		encoded[i], _ = tid.MarshalBinary()
+	}
+
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 128)
+		var err error
+
+		for b.Loop() {
+			tid := testTypeIDs[b.N%len(testTypeIDs)]
+			buf, err = tid.AppendBinary(buf[:0])
+		}
+
+		sinkBytes = buf
+		sinkError = err
+	})
+
+	b.Run("unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		var tid typeid.TypeID
+		var err error
+
+		for b.Loop() {
+			err = tid.UnmarshalBinary(encoded[b.N%len(encoded)])
+		}
+
+		sinkTypeID = tid
+		sinkError = err
+	})
+}
+
+// BenchmarkFindAll measures how fast TypeIDs are extracted from log lines
+func BenchmarkFindAll(b *testing.B) {
+	var log []byte
+	for i, tid := range testTypeIDs {
+		log = fmt.Appendf(log, "2024-01-01T00:00:00Z INFO request=%d handled id=%s status=200\n", i, tid)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(log)))
+	count := 0
+
+	for b.Loop() {
+		for range typeid.FindAll(log) {
+			count++
+		}
+	}
+
+	sinkInt = count
+}
 
-type TestID struct {
-	typeid.TypeID
+// BenchmarkObfuscate measures the cost of the cycle-walking block permutation
+func BenchmarkObfuscate(b *testing.B) {
+	obf, err := typeid.NewObfuscator(typeid.ObfuscationKey{ID: 1, Secret: make([]byte, 16)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	tid := typeid.MustGenerate("user")
+	public, _ := obf.Obfuscate(tid)
+
+	b.Run("obfuscate", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			sinkTypeID, sinkError = obf.Obfuscate(tid)
+		}
+	})
+
+	b.Run("reveal", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			sinkTypeID, sinkError = obf.Reveal(public)
+		}
+	})
 }
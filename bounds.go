@@ -0,0 +1,89 @@
+package typeid
+
+import (
+	"fmt"
+	"time"
+
+	"go.jetify.com/typeid/v2/base32"
+)
+
+// Because the suffix of a UUIDv7-based TypeID starts with its creation timestamp,
+// and the base32 alphabet is in ascending ASCII order, TypeIDs with the same prefix
+// sort by creation time both as strings and as UUID bytes. That lets a range
+// query on an id column stand in for a separate created_at index:
+//
+//	from, to, err := typeid.TimeRange("order", start, end)
+//	rows, err := db.Query("SELECT ... WHERE id >= $1 AND id < $2", from, to)
+//
+// Text columns must use a byte-wise collation (such as "C") for string
+// comparison to match id ordering.
+
+// maxTimestamp is the largest millisecond timestamp a UUIDv7 can hold.
+const maxTimestamp = 1<<48 - 1
+
+// MinForTime returns the smallest UUIDv7-based TypeID with the given prefix that
+// was created at instant t. The time is truncated to millisecond precision.
+func MinForTime(prefix string, t time.Time) (TypeID, error) {
+	return boundForTime(prefix, t, 0x00)
+}
+
+// MaxForTime returns the largest UUIDv7-based TypeID with the given prefix that
+// was created at instant t. The time is truncated to millisecond precision.
+func MaxForTime(prefix string, t time.Time) (TypeID, error) {
+	return boundForTime(prefix, t, 0xff)
+}
+
+// TimeRange returns the bounds of the half-open window [from, to) for TypeIDs
+// with the given prefix, so that every id created in the window satisfies
+// lower <= id < upper. Ids only record the millisecond they were created in, so
+// from is truncated and to is rounded up to a whole millisecond: when either has
+// a sub-millisecond part, ids created in that millisecond but outside the
+// window are within the bounds too. For whole milliseconds, an id is within the
+// bounds if and only if it was created in the window.
+func TimeRange(prefix string, from, to time.Time) (lower, upper TypeID, err error) {
+	if to.Before(from) {
+		return zeroID, zeroID, &validationError{
+			Message: fmt.Sprintf("time range end %v is before start %v", to, from),
+		}
+	}
+	if truncated := to.Truncate(time.Millisecond); !truncated.Equal(to) {
+		to = truncated.Add(time.Millisecond)
+	}
+	lower, err = MinForTime(prefix, from)
+	if err != nil {
+		return zeroID, zeroID, err
+	}
+	upper, err = MinForTime(prefix, to)
+	if err != nil {
+		return zeroID, zeroID, err
+	}
+	return lower, upper, nil
+}
+
+// boundForTime builds a UUIDv7 TypeID at instant t whose random bits are all
+// set to fill.
+func boundForTime(prefix string, t time.Time, fill byte) (TypeID, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return zeroID, err
+	}
+
+	ms := t.UnixMilli()
+	if ms < 0 || ms > maxTimestamp {
+		return zeroID, &validationError{
+			Message: fmt.Sprintf("time %v is outside the UUIDv7 timestamp range", t),
+		}
+	}
+
+	var uid [16]byte
+	for i := 6; i < len(uid); i++ {
+		uid[i] = fill
+	}
+	putTimestamp(&uid, uint64(ms))
+	setVersion7(&uid)
+
+	// Use stack buffer for base32 encoding to avoid allocation
+	var suffixBuf [26]byte
+	base32.Encode(suffixBuf[:], uid)
+
+	return newTypeID(prefix, suffixBuf), nil
+}
@@ -0,0 +1,122 @@
+package typeid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestMinMaxForTime(t *testing.T) {
+	instant := time.UnixMilli(0x01890a5dac96).Add(500 * time.Microsecond)
+
+	lower, err := typeid.MinForTime("prefix", instant)
+	require.NoError(t, err)
+	upper, err := typeid.MaxForTime("prefix", instant)
+	require.NoError(t, err)
+
+	assert.Equal(t, "prefix_01h455vb4pe008000000000000", lower.String())
+	assert.Equal(t, "prefix_01h455vb4pfzzvzzzzzzzzzzzz", upper.String())
+	assert.Equal(t, "01890a5d-ac96-7000-8000-000000000000", lower.UUID())
+	assert.Equal(t, "01890a5d-ac96-7fff-bfff-ffffffffffff", upper.UUID())
+
+	// Both bounds are UUIDv7s carrying the truncated instant
+	for _, tid := range []typeid.TypeID{lower, upper} {
+		created, ok := tid.Time()
+		require.True(t, ok)
+		assert.Equal(t, instant.Truncate(time.Millisecond).UTC(), created)
+	}
+
+	// The spec's uuidv7 example at the same millisecond falls between the bounds
+	example := typeid.MustParse("prefix_01h455vb4pex5vsknk084sn02q")
+	assert.LessOrEqual(t, lower.String(), example.String())
+	assert.GreaterOrEqual(t, upper.String(), example.String())
+
+	// Bounds are usable as SQL parameters
+	value, err := lower.Value()
+	require.NoError(t, err)
+	assert.Equal(t, lower.String(), value)
+}
+
+func TestTimeRange(t *testing.T) {
+	from := time.Now().Truncate(time.Millisecond)
+	to := from.Add(time.Hour)
+
+	lower, upper, err := typeid.TimeRange("order", from, to)
+	require.NoError(t, err)
+
+	inside := []time.Time{from, from.Add(time.Minute), to.Add(-time.Millisecond)}
+	outside := []time.Time{from.Add(-time.Millisecond), to, to.Add(time.Minute)}
+
+	for _, instant := range inside {
+		gen := typeid.NewGenerator(typeid.WithClock(func() time.Time { return instant }))
+		id := gen.MustGenerate("order").String()
+		assert.True(t, lower.String() <= id && id < upper.String(), "%s created at %v should be in range", id, instant)
+	}
+	for _, instant := range outside {
+		gen := typeid.NewGenerator(typeid.WithClock(func() time.Time { return instant }))
+		id := gen.MustGenerate("order").String()
+		assert.False(t, lower.String() <= id && id < upper.String(), "%s created at %v should not be in range", id, instant)
+	}
+}
+
+func TestTimeRangeSubMillisecond(t *testing.T) {
+	from := time.UnixMilli(2000).Add(500 * time.Microsecond)
+	to := time.UnixMilli(3000).Add(500 * time.Microsecond)
+	lower, upper, err := typeid.TimeRange("order", from, to)
+	require.NoError(t, err)
+
+	// Ids created in the window's first and last partial milliseconds are
+	// within the bounds
+	for _, instant := range []time.Time{from.Add(200 * time.Microsecond), to.Add(-300 * time.Microsecond)} {
+		gen := typeid.NewGenerator(typeid.WithClock(func() time.Time { return instant }))
+		id := gen.MustGenerate("order").String()
+		assert.True(t, lower.String() <= id && id < upper.String(), "%s created at %v should be in range", id, instant)
+	}
+	assert.Equal(t, mustMinForTime(t, time.UnixMilli(2000)), lower)
+	assert.Equal(t, mustMinForTime(t, time.UnixMilli(3001)), upper)
+}
+
+func mustMinForTime(t *testing.T, instant time.Time) typeid.TypeID {
+	t.Helper()
+	tid, err := typeid.MinForTime("order", instant)
+	require.NoError(t, err)
+	return tid
+}
+
+func TestTimeRangeErrors(t *testing.T) {
+	now := time.Now()
+
+	testdata := []struct {
+		name string
+		fn   func() error
+	}{
+		{"invalid prefix", func() error {
+			_, err := typeid.MinForTime("INVALID", now)
+			return err
+		}},
+		{"before epoch", func() error {
+			_, err := typeid.MaxForTime("user", time.UnixMilli(-1))
+			return err
+		}},
+		{"after max timestamp", func() error {
+			_, err := typeid.MinForTime("user", time.UnixMilli(1<<48))
+			return err
+		}},
+		{"reversed range", func() error {
+			_, _, err := typeid.TimeRange("user", now, now.Add(-time.Second))
+			return err
+		}},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			err := td.fn()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+		})
+	}
+}
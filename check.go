@@ -0,0 +1,115 @@
+package typeid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkSymbols are the Crockford base32 check symbols, in lowercase to match
+// the suffix alphabet. Values 0 to 31 use the suffix alphabet and 32 to 36 the
+// extra symbols *, ~, $, = and u.
+const checkSymbols = "0123456789abcdefghjkmnpqrstvwxyz*~$=u"
+
+// StringWithCheck returns the TypeID in its canonical form followed by a
+// Crockford check character, for ids that people read aloud and type in:
+//
+//	user_01h455vb4pex5vsknk084sn02q  ->  user_01h455vb4pex5vsknk084sn02qc
+//
+// The check character is the prefix bytes followed by the 16 UUID bytes, read
+// as one big-endian number, modulo 37. It detects every single character
+// substitution and every transposition of adjacent characters, in the prefix
+// as well as in the suffix. Parse the result with ParseWithCheck.
+func (tid TypeID) StringWithCheck() string {
+	return tid.String() + string(checkSymbol(tid.Prefix(), tid.uuidBytes()))
+}
+
+// StringWithCheck returns the ID followed by a check character, see
+// TypeID.StringWithCheck.
+func (id ID[P]) StringWithCheck() string {
+	return id.tid.StringWithCheck()
+}
+
+// checkSymbol returns the check character of the id with the given prefix and
+// UUID.
+func checkSymbol(prefix string, uid [16]byte) byte {
+	var mod uint
+	for _, b := range []byte(prefix) {
+		mod = (mod<<8 | uint(b)) % 37
+	}
+	for _, b := range uid {
+		mod = (mod<<8 | uint(b)) % 37
+	}
+	return checkSymbols[mod]
+}
+
+// CheckErrorKind identifies what's wrong with an id parsed by ParseWithCheck.
+type CheckErrorKind uint8
+
+const (
+	// BadBody means the id before the check character is malformed.
+	BadBody CheckErrorKind = iota + 1
+	// BadCheck means the check character is missing or isn't a check symbol.
+	BadCheck
+	// CheckMismatch means both parts are well-formed but the check character
+	// doesn't match the body, usually because of a typo in the body.
+	CheckMismatch
+)
+
+// CheckError is returned by ParseWithCheck. Kind tells whether the error is in
+// the body or in the check character, and Err is the underlying validation
+// error, which reports the offset of the offending character when it's known.
+//
+// It matches ErrValidation with errors.Is.
+type CheckError struct {
+	Kind CheckErrorKind
+	Err  error
+}
+
+// Error implements the error interface
+func (e *CheckError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error
+func (e *CheckError) Unwrap() error {
+	return e.Err
+}
+
+// ParseWithCheck parses a TypeID followed by its check character, as returned
+// by StringWithCheck. It returns a *CheckError if s is malformed or the check
+// character doesn't match. A string that is a valid TypeID on its own is
+// reported as missing its check character.
+func ParseWithCheck(s string) (TypeID, error) {
+	if s == "" {
+		return zeroID, &CheckError{Kind: BadCheck, Err: &validationError{
+			Message: "missing check character",
+			pos:     1,
+		}}
+	}
+
+	// A valid id on its own is missing the check character
+	if _, err := Parse(s); err == nil {
+		return zeroID, &CheckError{Kind: BadCheck, Err: &validationError{
+			Message: "missing check character",
+			pos:     len(s) + 1,
+		}}
+	}
+
+	body, check := s[:len(s)-1], s[len(s)-1]
+	tid, err := Parse(body)
+	if err != nil {
+		return zeroID, &CheckError{Kind: BadBody, Err: err}
+	}
+	if strings.IndexByte(checkSymbols, check) == -1 {
+		return zeroID, &CheckError{Kind: BadCheck, Err: &validationError{
+			Message: fmt.Sprintf("invalid check character %q", check),
+			pos:     len(s),
+		}}
+	}
+	if check != checkSymbol(tid.Prefix(), tid.uuidBytes()) {
+		return zeroID, &CheckError{Kind: CheckMismatch, Err: &validationError{
+			Message: fmt.Sprintf("check character %q doesn't match the id", check),
+		}}
+	}
+	return tid, nil
+}
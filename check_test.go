@@ -0,0 +1,127 @@
+package typeid_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+const checkSymbols = "0123456789abcdefghjkmnpqrstvwxyz*~$=u"
+
+func TestStringWithCheck(t *testing.T) {
+	var testdata []ValidExample
+	require.NoError(t, yaml.Unmarshal(validYML, &testdata))
+
+	for _, td := range testdata {
+		t.Run(td.Name, func(t *testing.T) {
+			tid := typeid.MustParse(td.Tid)
+			checked := tid.StringWithCheck()
+			assert.Equal(t, td.Tid, tid.String(), "String must not change")
+
+			// The check character is the prefix and UUID bytes modulo 37
+			n, ok := new(big.Int).SetString(hex.EncodeToString([]byte(td.Prefix))+strings.ReplaceAll(td.UUID, "-", ""), 16)
+			require.True(t, ok)
+			mod := new(big.Int).Mod(n, big.NewInt(37)).Int64()
+			assert.Equal(t, td.Tid+string(checkSymbols[mod]), checked)
+
+			parsed, err := typeid.ParseWithCheck(checked)
+			require.NoError(t, err)
+			assert.Equal(t, tid, parsed)
+		})
+	}
+
+	id, err := typeid.FromTypeID[TestPrefix](typeid.MustParse("test_01h455vb4pex5vsknk084sn02q"))
+	require.NoError(t, err)
+	assert.Equal(t, "test_01h455vb4pex5vsknk084sn02qd", id.StringWithCheck())
+}
+
+// TestCheckDetectsTypos checks that every single character substitution and
+// adjacent transposition in the prefix and the suffix is detected.
+func TestCheckDetectsTypos(t *testing.T) {
+	const (
+		prefixAlphabet = "abcdefghijklmnopqrstuvwxyz_"
+		suffixAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+	)
+	for range 20 {
+		checked := typeid.MustGenerate("user_account").StringWithCheck()
+		sep := len("user_account")
+		end := len(checked) - 1
+
+		for i := 0; i < end; i++ {
+			alphabet := suffixAlphabet
+			if i < sep {
+				alphabet = prefixAlphabet
+			} else if i == sep {
+				continue
+			}
+			for _, c := range []byte(alphabet) {
+				if c == checked[i] {
+					continue
+				}
+				typo := checked[:i] + string(c) + checked[i+1:]
+				_, err := typeid.ParseWithCheck(typo)
+				require.Error(t, err, typo)
+			}
+			if i+1 < end && checked[i] != checked[i+1] {
+				typo := checked[:i] + string(checked[i+1]) + string(checked[i]) + checked[i+2:]
+				_, err := typeid.ParseWithCheck(typo)
+				require.Error(t, err, typo)
+			}
+		}
+	}
+}
+
+func TestParseWithCheckErrors(t *testing.T) {
+	const valid = "user_01h455vb4pex5vsknk084sn02qc"
+
+	testdata := []struct {
+		name     string
+		input    string
+		kind     typeid.CheckErrorKind
+		expected string
+		offset   int // -1 if unknown
+	}{
+		{"empty", "", typeid.BadCheck, "typeid: missing check character", 0},
+		{"missing check", "user_01h455vb4pex5vsknk084sn02q", typeid.BadCheck, "typeid: missing check character", 31},
+		{"invalid check", "user_01h455vb4pex5vsknk084sn02q!", typeid.BadCheck, `typeid: invalid check character '!'`, 31},
+		{"uppercase check", "user_01h455vb4pex5vsknk084sn02qA", typeid.BadCheck, `typeid: invalid check character 'A'`, 31},
+		{"invalid body", "user_01h455vb4pex5vsknk084sn0uqc", typeid.BadBody, "typeid: invalid suffix encoding: illegal base32 data at offset 24", 29},
+		{"invalid prefix", "User_01h455vb4pex5vsknk084sn02qc", typeid.BadBody, `typeid: prefix must contain only [a-z_], found 'U' in "User"`, 0},
+		{"short body", "user_01h455vb4pex5vsknk084sn0c", typeid.BadBody, "typeid: suffix length must be 26, got 24", 29},
+		// With a character dropped, the id and check character form a valid id
+		{"dropped character", "user_01h455vb4pex5vsknk084sn2qc", typeid.BadCheck, "typeid: missing check character", 31},
+		{"typo in body", strings.Replace(valid, "vb4", "vb5", 1), typeid.CheckMismatch, `typeid: check character 'c' doesn't match the id`, -1},
+		{"typo in prefix", strings.Replace(valid, "user", "usar", 1), typeid.CheckMismatch, `typeid: check character 'c' doesn't match the id`, -1},
+		{"typo in check", valid[:len(valid)-1] + "b", typeid.CheckMismatch, `typeid: check character 'b' doesn't match the id`, -1},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			tid, err := typeid.ParseWithCheck(td.input)
+			assert.True(t, tid.IsZero())
+			assert.EqualError(t, err, td.expected)
+			assert.True(t, errors.Is(err, typeid.ErrValidation))
+
+			var checkErr *typeid.CheckError
+			require.True(t, errors.As(err, &checkErr))
+			assert.Equal(t, td.kind, checkErr.Kind)
+
+			var located interface{ Offset() (int, bool) }
+			require.True(t, errors.As(err, &located))
+			offset, ok := located.Offset()
+			if td.offset == -1 {
+				assert.False(t, ok)
+			} else {
+				assert.True(t, ok)
+				assert.Equal(t, td.offset, offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"go.jetify.com/typeid/v2"
+)
+
+// The conformance command checks that another TypeID implementation agrees
+// with the spec files in testdata/. The implementation runs as a subprocess
+// and speaks JSON Lines: the runner writes one request per line to its stdin
+// and the implementation answers each with one line on its stdout.
+//
+//	{"op":"parse","typeid":"user_01h455vb4pex5vsknk084sn02q"}
+//	{"prefix":"user","uuid":"01890a5d-ac96-774b-bcce-b302099a8057"}
+//
+//	{"op":"encode","prefix":"user","uuid":"01890a5d-ac96-774b-bcce-b302099a8057"}
+//	{"typeid":"user_01h455vb4pex5vsknk084sn02q"}
+//
+// An id that can't be parsed or encoded is answered with {"error":"message"}.
+// Missing fields are treated as empty strings.
+
+// runConformance implements "typeid conformance".
+func runConformance(e *env, args []string) error {
+	fs := newFlagSet(e, "conformance", "command [arg ...]",
+		"Runs another TypeID implementation as a subprocess and checks its answers\n"+
+			"against the spec's valid.yml and invalid.yml. The implementation reads JSON\n"+
+			"requests from stdin, one per line, and answers each with one JSON line:\n\n"+
+			`  {"op":"parse","typeid":"..."}             -> {"prefix":"...","uuid":"..."}`+"\n"+
+			`  {"op":"encode","prefix":"...","uuid":"..."} -> {"typeid":"..."}`+"\n\n"+
+			`Ids that are rejected are answered with {"error":"..."}. Exits with status 1`+"\n"+
+			"if any check fails. With -serve, this implementation answers the requests\n"+
+			"instead, so the runner can be checked against it.")
+	dir := fs.String("testdata", "testdata", "`directory` containing valid.yml and invalid.yml")
+	timeout := fs.Duration("timeout", time.Minute, "time limit for the whole run")
+	verbose := fs.Bool("v", false, "also report the checks that pass")
+	serve := fs.Bool("serve", false, "answer requests on stdin instead of running an implementation")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *serve {
+		if fs.NArg() > 0 {
+			return usageErrorf(fs, "-serve takes no arguments")
+		}
+		return serveConformance(e.stdin, e.stdout, typeid.Parse)
+	}
+	if fs.NArg() == 0 {
+		return usageErrorf(fs, "missing implementation command")
+	}
+
+	var valid []validExample
+	if err := readSpec(filepath.Join(*dir, "valid.yml"), &valid); err != nil {
+		return err
+	}
+	var invalid []invalidExample
+	if err := readSpec(filepath.Join(*dir, "invalid.yml"), &invalid); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	impl, err := startImplementation(ctx, fs.Args(), e.stderr)
+	if err != nil {
+		return err
+	}
+
+	r := &conformanceReport{w: bufio.NewWriter(e.stdout), verbose: *verbose}
+	err = runChecks(impl, r, valid, invalid)
+	if closeErr := impl.Close(); err == nil {
+		err = closeErr
+	}
+	r.Summary()
+	if flushErr := r.w.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+	if r.failed() {
+		return errFailed
+	}
+	return nil
+}
+
+// validExample is an entry of valid.yml.
+type validExample struct {
+	Name   string `yaml:"name"`
+	TypeID string `yaml:"typeid"`
+	Prefix string `yaml:"prefix"`
+	UUID   string `yaml:"uuid"`
+}
+
+// invalidExample is an entry of invalid.yml.
+type invalidExample struct {
+	Name   string `yaml:"name"`
+	TypeID string `yaml:"typeid"`
+}
+
+// readSpec decodes the named spec file into examples.
+func readSpec(name string, examples any) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(data, examples); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// runChecks checks every example against impl and records the results in r.
+func runChecks(impl *implementation, r *conformanceReport, valid []validExample, invalid []invalidExample) error {
+	for _, ex := range valid {
+		resp, err := impl.call(parseRequest{Op: "parse", TypeID: ex.TypeID})
+		if err != nil {
+			return err
+		}
+		switch {
+		case resp.malformed != "":
+			r.Fail("parse", ex.Name, "%s", resp.malformed)
+		case resp.Error != "":
+			r.Fail("parse", ex.Name, "rejected %q: %s", ex.TypeID, resp.Error)
+		case resp.Prefix != ex.Prefix || !strings.EqualFold(resp.UUID, ex.UUID):
+			r.Fail("parse", ex.Name, "got prefix %q and uuid %q, want %q and %q", resp.Prefix, resp.UUID, ex.Prefix, ex.UUID)
+		default:
+			r.Pass("parse", ex.Name)
+		}
+
+		resp, err = impl.call(encodeRequest{Op: "encode", Prefix: ex.Prefix, UUID: ex.UUID})
+		if err != nil {
+			return err
+		}
+		switch {
+		case resp.malformed != "":
+			r.Fail("encode", ex.Name, "%s", resp.malformed)
+		case resp.Error != "":
+			r.Fail("encode", ex.Name, "rejected prefix %q and uuid %q: %s", ex.Prefix, ex.UUID, resp.Error)
+		case resp.TypeID != ex.TypeID:
+			r.Fail("encode", ex.Name, "got %q, want %q", resp.TypeID, ex.TypeID)
+		default:
+			r.Pass("encode", ex.Name)
+		}
+	}
+
+	for _, ex := range invalid {
+		resp, err := impl.call(parseRequest{Op: "parse", TypeID: ex.TypeID})
+		if err != nil {
+			return err
+		}
+		switch {
+		case resp.malformed != "":
+			r.Fail("reject", ex.Name, "%s", resp.malformed)
+		case resp.Error == "":
+			r.Fail("reject", ex.Name, "accepted %q as prefix %q and uuid %q", ex.TypeID, resp.Prefix, resp.UUID)
+		default:
+			r.Pass("reject", ex.Name)
+		}
+	}
+	return nil
+}
+
+// parseRequest asks the implementation to decode a TypeID.
+type parseRequest struct {
+	Op     string `json:"op"`
+	TypeID string `json:"typeid"`
+}
+
+// encodeRequest asks the implementation to encode a UUID as a TypeID.
+type encodeRequest struct {
+	Op     string `json:"op"`
+	Prefix string `json:"prefix"`
+	UUID   string `json:"uuid"`
+}
+
+// conformanceResponse is the answer to a request. Only the fields that apply
+// to the request are set.
+type conformanceResponse struct {
+	TypeID string `json:"typeid,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	UUID   string `json:"uuid,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	// malformed describes a response that isn't valid JSON. It fails every
+	// check, including those expecting an error.
+	malformed string
+}
+
+// implementation is a running implementation under test.
+type implementation struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+}
+
+// startImplementation starts the command args. Its stderr is passed through to
+// stderr, and it's killed when ctx is done.
+func startImplementation(ctx context.Context, args []string, stderr io.Writer) (*implementation, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stderr = stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &implementation{cmd: cmd, stdin: stdin, stdout: bufio.NewScanner(stdout)}, nil
+}
+
+// call sends req and waits for the answer. A response that isn't valid JSON is
+// returned with malformed set, so it fails the check without stopping the run.
+func (impl *implementation) call(req any) (conformanceResponse, error) {
+	line, err := json.Marshal(req)
+	if err != nil {
+		return conformanceResponse{}, err
+	}
+	if _, err := impl.stdin.Write(append(line, '\n')); err != nil {
+		return conformanceResponse{}, fmt.Errorf("implementation stopped reading requests: %w", err)
+	}
+	if !impl.stdout.Scan() {
+		if err := impl.stdout.Err(); err != nil {
+			return conformanceResponse{}, err
+		}
+		return conformanceResponse{}, errors.New("implementation exited without answering " + string(line))
+	}
+
+	var resp conformanceResponse
+	if err := json.Unmarshal(impl.stdout.Bytes(), &resp); err != nil {
+		resp = conformanceResponse{
+			malformed: fmt.Sprintf("invalid response %q: %v", impl.stdout.Text(), err),
+		}
+	}
+	return resp, nil
+}
+
+// Close closes the implementation's stdin and waits for it to exit.
+func (impl *implementation) Close() error {
+	impl.stdin.Close()
+	if err := impl.cmd.Wait(); err != nil {
+		return fmt.Errorf("implementation: %w", err)
+	}
+	return nil
+}
+
+// conformanceReport prints the result of each check and keeps totals per kind
+// of check.
+type conformanceReport struct {
+	w       *bufio.Writer
+	verbose bool
+	kinds   []string
+	passed  map[string]int
+	total   map[string]int
+}
+
+func (r *conformanceReport) record(kind string, ok bool) {
+	if r.total == nil {
+		r.passed, r.total = map[string]int{}, map[string]int{}
+	}
+	if _, seen := r.total[kind]; !seen {
+		r.kinds = append(r.kinds, kind)
+	}
+	r.total[kind]++
+	if ok {
+		r.passed[kind]++
+	}
+}
+
+// Pass records a passing check, printed only in verbose mode.
+func (r *conformanceReport) Pass(kind, name string) {
+	r.record(kind, true)
+	if r.verbose {
+		fmt.Fprintf(r.w, "PASS %s %s\n", kind, name)
+	}
+}
+
+// Fail records and prints a failing check.
+func (r *conformanceReport) Fail(kind, name, format string, args ...any) {
+	r.record(kind, false)
+	fmt.Fprintf(r.w, "FAIL %s %s: %s\n", kind, name, fmt.Sprintf(format, args...))
+}
+
+// Summary prints the totals per kind of check.
+func (r *conformanceReport) Summary() {
+	for _, kind := range r.kinds {
+		fmt.Fprintf(r.w, "%-7s %d/%d passed\n", kind+":", r.passed[kind], r.total[kind])
+	}
+}
+
+func (r *conformanceReport) failed() bool {
+	for _, kind := range r.kinds {
+		if r.passed[kind] < r.total[kind] {
+			return true
+		}
+	}
+	return false
+}
+
+// serveConformance answers conformance requests read from r, using parse to
+// decode ids. Each answer is flushed immediately, as the runner waits for it
+// before sending the next request.
+func serveConformance(r io.Reader, w io.Writer, parse func(string) (typeid.TypeID, error)) error {
+	scanner := bufio.NewScanner(r)
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for scanner.Scan() {
+		var req struct {
+			Op     string `json:"op"`
+			TypeID string `json:"typeid"`
+			Prefix string `json:"prefix"`
+			UUID   string `json:"uuid"`
+		}
+		var resp conformanceResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = err.Error()
+		} else {
+			switch req.Op {
+			case "parse":
+				if tid, err := parse(req.TypeID); err != nil {
+					resp.Error = err.Error()
+				} else {
+					resp.Prefix, resp.UUID = tid.Prefix(), tid.UUID()
+				}
+			case "encode":
+				if tid, err := typeid.FromUUID(req.Prefix, req.UUID); err != nil {
+					resp.Error = err.Error()
+				} else {
+					resp.TypeID = tid.String()
+				}
+			default:
+				resp.Error = fmt.Sprintf("unknown op %q", req.Op)
+			}
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.jetify.com/typeid/v2"
+)
+
+// TestFakeImplementation isn't a real test: conformance tests run the test
+// binary itself as the implementation under test, selecting this function and
+// a behavior with the TYPEID_FAKE_IMPLEMENTATION environment variable.
+func TestFakeImplementation(t *testing.T) {
+	var err error
+	switch os.Getenv("TYPEID_FAKE_IMPLEMENTATION") {
+	case "":
+		t.Skip("only run as a fake implementation")
+	case "conforming":
+		err = serveConformance(os.Stdin, os.Stdout, typeid.Parse)
+	case "lenient":
+		// Accepts uppercase ids, which the spec rejects
+		err = serveConformance(os.Stdin, os.Stdout, func(s string) (typeid.TypeID, error) {
+			return typeid.Parse(strings.ToLower(s))
+		})
+	case "garbled":
+		os.Stdout.WriteString("not json\n")
+		err = serveConformance(os.Stdin, os.Stdout, typeid.Parse)
+	case "garbage":
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			os.Stdout.WriteString("garbage\n")
+		}
+	case "crashing":
+		os.Exit(3)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// runConformanceAgainst runs the conformance command against the fake
+// implementation with the given behavior.
+func runConformanceAgainst(t *testing.T, behavior string, flags ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	t.Setenv("TYPEID_FAKE_IMPLEMENTATION", behavior)
+	args := append([]string{"conformance", "-testdata", "../../testdata"}, flags...)
+	args = append(args, os.Args[0], "-test.run=^TestFakeImplementation$")
+	return runCommand(t, "", args...)
+}
+
+func TestConformancePass(t *testing.T) {
+	stdout, stderr, code := runConformanceAgainst(t, "conforming")
+	assert.Equal(t, 0, code, stderr)
+	assert.NotContains(t, stdout, "FAIL")
+	assert.Contains(t, stdout, "parse:  9/9 passed\n")
+	assert.Contains(t, stdout, "encode: 9/9 passed\n")
+	assert.Contains(t, stdout, "reject: 22/22 passed\n")
+
+	stdout, _, code = runConformanceAgainst(t, "conforming", "-v")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "PASS parse nil\n")
+	assert.Contains(t, stdout, "PASS reject prefix-uppercase\n")
+}
+
+func TestConformanceFailures(t *testing.T) {
+	stdout, _, code := runConformanceAgainst(t, "lenient")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `FAIL reject prefix-uppercase: accepted "PREFIX_00000000000000000000000000" as prefix "prefix" and uuid "00000000-0000-0000-0000-000000000000"`)
+	assert.NotContains(t, stdout, "FAIL parse")
+	assert.Contains(t, stdout, "reject: 20/22 passed\n")
+
+	// An invalid response fails a check, and shifts every answer after it
+	stdout, _, code = runConformanceAgainst(t, "garbled")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `FAIL parse nil: invalid response "not json"`)
+	assert.Contains(t, stdout, "parse:  0/9 passed\n")
+	assert.Contains(t, stdout, `FAIL reject prefix-uppercase: accepted "PREFIX_00000000000000000000000000" as prefix "" and uuid ""`)
+	assert.Contains(t, stdout, "reject: 21/22 passed\n")
+
+	// Invalid responses never count as rejections
+	stdout, _, code = runConformanceAgainst(t, "garbage")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `FAIL reject prefix-uppercase: invalid response "garbage"`)
+	assert.Contains(t, stdout, "parse:  0/9 passed\n")
+	assert.Contains(t, stdout, "encode: 0/9 passed\n")
+	assert.Contains(t, stdout, "reject: 0/22 passed\n")
+}
+
+func TestConformanceErrors(t *testing.T) {
+	_, stderr, code := runConformanceAgainst(t, "crashing")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "typeid conformance: implementation")
+
+	_, stderr, code = runCommand(t, "", "conformance")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "missing implementation command")
+
+	_, stderr, code = runCommand(t, "", "conformance", "-testdata", t.TempDir(), "true")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "valid.yml")
+}
+
+func TestConformanceServe(t *testing.T) {
+	input := `{"op":"parse","typeid":"user_` + testSuffix + `"}` + "\n" +
+		`{"op":"encode","prefix":"user","uuid":"` + testUUID + `"}` + "\n" +
+		`{"op":"parse","typeid":"User_` + testSuffix + `"}` + "\n" +
+		`{"op":"delete"}` + "\n"
+	stdout, stderr, code := runCommand(t, input, "conformance", "-serve")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, `{"prefix":"user","uuid":"`+testUUID+`"}`+"\n"+
+		`{"typeid":"user_`+testSuffix+`"}`+"\n"+
+		`{"error":"typeid: prefix must contain only [a-z_], found 'U' in \"User\""}`+"\n"+
+		`{"error":"unknown op \"delete\""}`+"\n", stdout)
+}
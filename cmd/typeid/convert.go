@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// runConvert implements "typeid convert".
+func runConvert(e *env, args []string) error {
+	fs := newFlagSet(e, "convert", "[file]",
+		"Streams a CSV or JSON Lines file and converts the named fields between UUIDs and\n"+
+			"TypeIDs. Reads stdin if no file is given and writes the result to stdout. Values\n"+
+			"and lines that fail to convert are reported with their line number and left\n"+
+			"unchanged. Empty, null and missing JSON values are left as they are.")
+	format := fs.String("format", "csv", "input `format`: csv or jsonl")
+	to := fs.String("to", "typeid", "convert fields `to` typeid (from uuid) or uuid (from typeid)")
+	prefix := fs.String("prefix", "", "default `prefix` for fields that don't specify one")
+	var fields fieldsFlag
+	fs.Var(&fields, "field", "`name[=prefix]` of a CSV column or dotted JSON path to convert; repeatable.\n"+
+		"The prefix is attached when converting to typeid and, if given, required when\n"+
+		"converting to uuid. Without one, ids with any prefix are converted to uuid")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if len(fields) == 0 {
+		return usageErrorf(fs, "at least one -field is required")
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf(fs, "too many arguments")
+	}
+
+	c := &converter{stderr: e.stderr, fields: fields}
+	for i := range c.fields {
+		if !c.fields[i].hasPrefix && isFlagSet(fs, "prefix") {
+			c.fields[i].prefix, c.fields[i].hasPrefix = *prefix, true
+		}
+	}
+	switch *to {
+	case "typeid":
+		c.convert = uuidToTypeID
+	case "uuid":
+		c.convert = typeIDToUUID
+	default:
+		return usageErrorf(fs, "invalid -to %q: must be typeid or uuid", *to)
+	}
+
+	in := e.stdin
+	if name := fs.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	w := bufio.NewWriterSize(e.stdout, 64*1024)
+	var err error
+	switch *format {
+	case "csv":
+		err = c.convertCSV(bufio.NewReaderSize(in, 64*1024), w)
+	case "jsonl":
+		err = c.convertJSONL(bufio.NewReaderSize(in, 64*1024), w)
+	default:
+		return usageErrorf(fs, "invalid -format %q: must be csv or jsonl", *format)
+	}
+	if flushErr := w.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+	if c.failures > 0 {
+		fmt.Fprintf(e.stderr, "typeid convert: %d value(s) failed to convert\n", c.failures)
+		return errFailed
+	}
+	return nil
+}
+
+// field is a field to convert, as given by the -field flag.
+type field struct {
+	name      string
+	prefix    string
+	hasPrefix bool // Whether a prefix was given, even an empty one
+}
+
+// fieldsFlag collects repeated -field flags.
+type fieldsFlag []field
+
+func (f *fieldsFlag) String() string {
+	names := make([]string, len(*f))
+	for i, fld := range *f {
+		names[i] = fld.name
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *fieldsFlag) Set(s string) error {
+	name, prefix, hasPrefix := strings.Cut(s, "=")
+	if name == "" {
+		return errors.New("field name cannot be empty")
+	}
+	*f = append(*f, field{name: name, prefix: prefix, hasPrefix: hasPrefix})
+	return nil
+}
+
+// converter rewrites fields of a stream, counting and reporting failures.
+type converter struct {
+	stderr   io.Writer
+	fields   []field
+	convert  func(value string, fld field) (string, error)
+	failures int
+}
+
+// uuidToTypeID converts a UUID to a TypeID with the field's prefix.
+func uuidToTypeID(value string, fld field) (string, error) {
+	tid, err := typeid.FromUUID(fld.prefix, value)
+	if err != nil {
+		return "", err
+	}
+	return tid.String(), nil
+}
+
+// typeIDToUUID converts a TypeID to a UUID. The TypeID must have the field's
+// prefix if one was given, and may have any prefix otherwise.
+func typeIDToUUID(value string, fld field) (string, error) {
+	parse := typeid.Parse
+	if fld.hasPrefix {
+		parse = func(s string) (typeid.TypeID, error) {
+			return typeid.ParseWithPrefix(s, fld.prefix)
+		}
+	}
+	tid, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	return tid.UUID(), nil
+}
+
+// convertValue converts value, reporting a failure for the given line and
+// returning the value unchanged. Empty values are left as they are.
+func (c *converter) convertValue(line int, fld field, value string) string {
+	if value == "" {
+		return value
+	}
+	converted, err := c.convert(value, fld)
+	if err != nil {
+		c.fail(line, fld.name+": ", err)
+		return value
+	}
+	return converted
+}
+
+// fail reports a failure on the given line.
+func (c *converter) fail(line int, context string, err error) {
+	c.failures++
+	fmt.Fprintf(c.stderr, "line %d: %s%v\n", line, context, err)
+}
+
+// convertCSV converts the named columns of a CSV stream with a header row.
+// Records are processed one at a time, so memory use doesn't grow with the input.
+func (c *converter) convertCSV(r io.Reader, w io.Writer) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	cw := csv.NewWriter(w)
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make([]int, len(c.fields))
+	for i, fld := range c.fields {
+		columns[i] = -1
+		for j, name := range header {
+			if name == fld.name {
+				columns[i] = j
+				break
+			}
+		}
+		if columns[i] == -1 {
+			return fmt.Errorf("column %q not found in header", fld.name)
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+		for i, col := range columns {
+			if col < len(record) {
+				record[col] = c.convertValue(line, c.fields[i], record[col])
+			} else {
+				c.fail(line, c.fields[i].name+": ", errors.New("missing column"))
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// convertJSONL converts the named paths of a JSON Lines stream. Each line is
+// rewritten in place: key order, formatting and untouched values are preserved.
+func (c *converter) convertJSONL(r *bufio.Reader, w io.Writer) error {
+	paths := make([][]string, len(c.fields))
+	for i, fld := range c.fields {
+		paths[i] = strings.Split(fld.name, ".")
+	}
+
+	for line := 1; ; line++ {
+		data, readErr := r.ReadBytes('\n')
+		if len(data) > 0 {
+			content := bytes.TrimRight(data, "\r\n")
+			newline := data[len(content):]
+			if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] != '{' {
+				c.fail(line, "", fmt.Errorf("expected a JSON object, got %s", jsonKind(trimmed)))
+			} else if len(trimmed) > 0 {
+				for i, path := range paths {
+					rewritten, err := rewriteJSON(content, path, func(value string) string {
+						return c.convertValue(line, c.fields[i], value)
+					})
+					var kindErr *jsonKindError
+					if errors.As(err, &kindErr) {
+						// Leave the value as it is and go on with the other fields
+						c.fail(line, c.fields[i].name+": ", err)
+						continue
+					}
+					if err != nil {
+						// Pass the line through unchanged, like values that fail to convert
+						c.fail(line, "invalid JSON: ", err)
+						content = bytes.TrimRight(data, "\r\n")
+						break
+					}
+					content = rewritten
+				}
+			}
+			if _, err := w.Write(content); err != nil {
+				return err
+			}
+			if _, err := w.Write(newline); err != nil {
+				return err
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// jsonKindError is returned by rewriteJSON when a value along the path has the
+// wrong JSON type.
+type jsonKindError struct {
+	want, got string
+}
+
+func (e *jsonKindError) Error() string {
+	return fmt.Sprintf("expected %s, got %s", e.want, e.got)
+}
+
+// jsonKind returns the JSON type of the encoded value data.
+func jsonKind(data []byte) string {
+	switch data[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case '"':
+		return "a string"
+	case 't', 'f':
+		return "a boolean"
+	case 'n':
+		return "null"
+	default:
+		return "a number"
+	}
+}
+
+// rewriteJSON replaces the string at path within the JSON object data with the
+// result of convert. Data is returned unchanged if the path doesn't exist or
+// leads to null. It returns a *jsonKindError if a value along the path isn't an
+// object, or the value at the path isn't a string.
+func rewriteJSON(data []byte, path []string, convert func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return data, nil
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if tok != path[0] {
+			continue
+		}
+
+		// The raw value starts after the colon and any whitespace
+		end := dec.InputOffset()
+		start := end - int64(len(value))
+
+		var replaced []byte
+		switch {
+		case value[0] == 'n':
+			return data, nil
+		case len(path) > 1:
+			if value[0] != '{' {
+				return nil, &jsonKindError{want: "an object", got: jsonKind(value)}
+			}
+			if replaced, err = rewriteJSON(value, path[1:], convert); err != nil {
+				return nil, err
+			}
+		default:
+			var s string
+			if value[0] != '"' {
+				return nil, &jsonKindError{want: "a string", got: jsonKind(value)}
+			}
+			if err := json.Unmarshal(value, &s); err != nil {
+				return nil, err
+			}
+			if replaced, err = json.Marshal(convert(s)); err != nil {
+				return nil, err
+			}
+		}
+
+		out := make([]byte, 0, len(data)-len(value)+len(replaced))
+		out = append(out, data[:start]...)
+		out = append(out, replaced...)
+		return append(out, data[end:]...), nil
+	}
+	return data, nil
+}
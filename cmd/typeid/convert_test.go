@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUUID   = "01890a5d-ac96-774b-bcce-b302099a8057"
+	testSuffix = "01h455vb4pex5vsknk084sn02q"
+)
+
+func TestConvertCSV(t *testing.T) {
+	input := "" +
+		"id,name,owner_id\n" +
+		testUUID + ",\"Smith, J\"," + testUUID + "\n" +
+		testUUID + ",\"multi\nline\",\n" +
+		"not-a-uuid,x," + testUUID + "\n"
+
+	stdout, stderr, code := runCommand(t, input, "convert", "-field", "id=user", "-field", "owner_id", "-prefix", "team")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, ""+
+		"id,name,owner_id\n"+
+		"user_"+testSuffix+",\"Smith, J\",team_"+testSuffix+"\n"+
+		"user_"+testSuffix+",\"multi\nline\",\n"+
+		"not-a-uuid,x,team_"+testSuffix+"\n", stdout)
+	assert.Contains(t, stderr, `line 5: id: typeid: invalid UUID format "not-a-uuid"`)
+	assert.Contains(t, stderr, "1 value(s) failed to convert")
+}
+
+func TestConvertCSVToUUID(t *testing.T) {
+	input := "id\nuser_" + testSuffix + "\norder_" + testSuffix + "\n"
+
+	stdout, stderr, code := runCommand(t, input, "convert", "-to", "uuid", "-field", "id=user")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "id\n"+testUUID+"\norder_"+testSuffix+"\n", stdout)
+	assert.Contains(t, stderr, `line 3: id: typeid: expected prefix "user", got "order"`)
+
+	// Without a prefix, ids with any prefix are converted
+	stdout, stderr, code = runCommand(t, input, "convert", "-to", "uuid", "-field", "id")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, "id\n"+testUUID+"\n"+testUUID+"\n", stdout)
+
+	// An empty prefix, given explicitly, requires ids without one
+	_, stderr, code = runCommand(t, input+testSuffix+"\n", "convert", "-to", "uuid", "-field", "id", "-prefix", "")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `line 2: id: typeid: expected prefix "", got "user"`)
+	assert.Contains(t, stderr, "2 value(s) failed to convert")
+
+	// Rows too short to have the column are reported
+	stdout, stderr, code = runCommand(t, "name,id\nx,user_"+testSuffix+"\ny\n", "convert", "-to", "uuid", "-field", "id")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "name,id\nx,"+testUUID+"\ny\n", stdout)
+	assert.Contains(t, stderr, "line 3: id: missing column\n")
+
+	_, stderr, code = runCommand(t, input, "convert", "-to", "uuid", "-field", "missing")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `column "missing" not found in header`)
+}
+
+func TestConvertJSONL(t *testing.T) {
+	input := "" +
+		`{"id": "` + testUUID + `", "meta": {"owner": "` + testUUID + `", "n": 1}, "tags": ["a"]}` + "\r\n" +
+		`{"id":null,"meta":{}}` + "\n" +
+		"\n" +
+		`{"id":"bad"}` + "\n" +
+		`{not json` + "\n" +
+		`{"meta":{"owner":"` + testUUID + `"}}`
+
+	stdout, stderr, code := runCommand(t, input, "convert", "-format", "jsonl", "-field", "id=user", "-field", "meta.owner=team")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, ""+
+		`{"id": "user_`+testSuffix+`", "meta": {"owner": "team_`+testSuffix+`", "n": 1}, "tags": ["a"]}`+"\r\n"+
+		`{"id":null,"meta":{}}`+"\n"+
+		"\n"+
+		`{"id":"bad"}`+"\n"+
+		`{not json`+"\n"+
+		`{"meta":{"owner":"team_`+testSuffix+`"}}`, stdout)
+	assert.Contains(t, stderr, "line 4: id: typeid: invalid UUID format")
+	assert.Contains(t, stderr, "line 5: invalid JSON")
+	assert.Contains(t, stderr, "2 value(s) failed to convert")
+
+	// Values that can't hold an id are reported and left as they are
+	input = "" +
+		`{"id":5,"meta":{"owner":"` + testUUID + `"}}` + "\n" +
+		`[1,2]` + "\n" +
+		`{"id":"` + testUUID + `","meta":"x"}` + "\n"
+	stdout, stderr, code = runCommand(t, input, "convert", "-format", "jsonl", "-field", "id=user", "-field", "meta.owner=team")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, ""+
+		`{"id":5,"meta":{"owner":"team_`+testSuffix+`"}}`+"\n"+
+		`[1,2]`+"\n"+
+		`{"id":"user_`+testSuffix+`","meta":"x"}`+"\n", stdout)
+	assert.Contains(t, stderr, "line 1: id: expected a string, got a number\n")
+	assert.Contains(t, stderr, "line 2: expected a JSON object, got an array\n")
+	assert.Contains(t, stderr, "line 3: meta.owner: expected an object, got a string\n")
+	assert.Contains(t, stderr, "3 value(s) failed to convert")
+}
+
+func TestConvertFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":"user_`+testSuffix+`"}`+"\n"), 0o600))
+
+	stdout, stderr, code := runCommand(t, "", "convert", "-format", "jsonl", "-to", "uuid", "-field", "id", "-prefix", "user", path)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, `{"id":"`+testUUID+`"}`+"\n", stdout)
+
+	_, stderr, code = runCommand(t, "", "convert", "-field", "id", filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no such file")
+}
+
+func TestConvertLarge(t *testing.T) {
+	var input strings.Builder
+	input.WriteString("id\n")
+	for range 10000 {
+		input.WriteString(testUUID + "\n")
+	}
+
+	stdout, stderr, code := runCommand(t, input.String(), "convert", "-field", "id=user")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, 10001, strings.Count(stdout, "\n"))
+}
+
+func TestConvertUsage(t *testing.T) {
+	testdata := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no fields", []string{"convert"}, "at least one -field is required"},
+		{"empty field", []string{"convert", "-field", "=user"}, "field name cannot be empty"},
+		{"invalid direction", []string{"convert", "-field", "id", "-to", "ulid"}, `invalid -to "ulid"`},
+		{"invalid format", []string{"convert", "-field", "id", "-format", "xml"}, `invalid -format "xml"`},
+		{"too many arguments", []string{"convert", "-field", "id", "a", "b"}, "too many arguments"},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			_, stderr, code := runCommand(t, "", td.args...)
+			assert.Equal(t, 2, code)
+			assert.Contains(t, stderr, td.want)
+		})
+	}
+}
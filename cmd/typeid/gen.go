@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// runGen implements "typeid gen".
+func runGen(e *env, args []string) error {
+	fs := newFlagSet(e, "gen", "[prefix]",
+		"Generates new TypeIDs with the given prefix, or without a prefix if none is given.")
+	count := fs.Int("n", 1, "number of ids to generate")
+	format := fs.String("format", "lines", "output `format`: lines, json or csv (with a uuid column)")
+	monotonic := fs.Bool("monotonic", false, "guarantee that the ids are strictly increasing")
+	at := fs.String("time", "", "pin the creation time to this RFC 3339 `timestamp`; implies -monotonic")
+	seed := fs.Uint64("seed", 0, "seed the random bits for reproducible output; requires -time and implies -monotonic")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 1 {
+		return usageErrorf(fs, "too many arguments")
+	}
+	prefix := fs.Arg(0)
+	if *count < 0 {
+		return usageErrorf(fs, "invalid -n %d: must not be negative", *count)
+	}
+
+	var opts []typeid.GeneratorOption
+	if *at != "" {
+		pinned, err := time.Parse(time.RFC3339Nano, *at)
+		if err != nil {
+			return usageErrorf(fs, "invalid -time %q: %v", *at, err)
+		}
+		opts = append(opts, typeid.WithClock(func() time.Time { return pinned }))
+	}
+	if isFlagSet(fs, "seed") {
+		// Without a pinned time, the timestamps would still differ between runs
+		if *at == "" {
+			return usageErrorf(fs, "-seed requires -time")
+		}
+		var key [32]byte
+		for i := range 8 {
+			key[i] = byte(*seed >> (8 * i))
+		}
+		opts = append(opts, typeid.WithEntropy(rand.NewChaCha8(key)))
+	}
+
+	var gen typeid.IDGenerator = typeid.GeneratorFunc(typeid.Generate)
+	if *monotonic || len(opts) > 0 {
+		gen = typeid.NewGenerator(opts...)
+	}
+
+	w, err := newIDWriter(e.stdout, *format)
+	if err != nil {
+		return usageErrorf(fs, "%v", err)
+	}
+	for range *count {
+		tid, err := gen.Generate(prefix)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(tid); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// idWriter writes a list of TypeIDs in one of the output formats.
+type idWriter interface {
+	Write(tid typeid.TypeID) error
+	// Close finishes the output and flushes it.
+	Close() error
+}
+
+// newIDWriter returns an idWriter for the named format.
+func newIDWriter(w io.Writer, format string) (idWriter, error) {
+	switch format {
+	case "lines":
+		return &linesWriter{w: bufio.NewWriter(w)}, nil
+	case "json":
+		return &jsonWriter{w: bufio.NewWriter(w)}, nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"typeid", "uuid"}); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	default:
+		return nil, fmt.Errorf("invalid -format %q: must be lines, json or csv", format)
+	}
+}
+
+// linesWriter writes one id per line.
+type linesWriter struct {
+	w *bufio.Writer
+}
+
+func (lw *linesWriter) Write(tid typeid.TypeID) error {
+	lw.w.WriteString(tid.String())
+	return lw.w.WriteByte('\n')
+}
+
+func (lw *linesWriter) Close() error {
+	return lw.w.Flush()
+}
+
+// jsonWriter writes the ids as a JSON array of strings, one id per line.
+type jsonWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func (jw *jsonWriter) Write(tid typeid.TypeID) error {
+	encoded, err := json.Marshal(tid)
+	if err != nil {
+		return err
+	}
+	if jw.count == 0 {
+		jw.w.WriteString("[\n  ")
+	} else {
+		jw.w.WriteString(",\n  ")
+	}
+	jw.count++
+	_, err = jw.w.Write(encoded)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	if jw.count == 0 {
+		jw.w.WriteString("[")
+	} else {
+		jw.w.WriteString("\n")
+	}
+	jw.w.WriteString("]\n")
+	return jw.w.Flush()
+}
+
+// csvWriter writes the ids as CSV with a typeid and a uuid column.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) Write(tid typeid.TypeID) error {
+	return cw.w.Write([]string{tid.String(), tid.UUID()})
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestGenLines(t *testing.T) {
+	stdout, stderr, code := runCommand(t, "", "gen", "-n", "5", "user")
+	require.Equal(t, 0, code, stderr)
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	require.Len(t, lines, 5)
+	for _, line := range lines {
+		_, err := typeid.ParseWithPrefix(line, "user")
+		assert.NoError(t, err)
+	}
+
+	stdout, _, code = runCommand(t, "", "gen")
+	require.Equal(t, 0, code)
+	tid, err := typeid.ParseWithPrefix(strings.TrimSpace(stdout), "")
+	require.NoError(t, err)
+	assert.True(t, tid.IsV7())
+}
+
+func TestGenJSON(t *testing.T) {
+	stdout, stderr, code := runCommand(t, "", "gen", "-n", "3", "-format", "json", "-monotonic", "order")
+	require.Equal(t, 0, code, stderr)
+
+	var ids []typeid.TypeID
+	require.NoError(t, json.Unmarshal([]byte(stdout), &ids))
+	assert.Len(t, ids, 3)
+	assert.True(t, slices.IsSortedFunc(ids, typeid.Compare))
+
+	stdout, _, code = runCommand(t, "", "gen", "-n", "0", "-format", "json")
+	require.Equal(t, 0, code)
+	assert.Equal(t, "[]\n", stdout)
+}
+
+func TestGenCSV(t *testing.T) {
+	stdout, stderr, code := runCommand(t, "", "gen", "-n", "2", "-format", "csv", "user")
+	require.Equal(t, 0, code, stderr)
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"typeid", "uuid"}, records[0])
+	for _, record := range records[1:] {
+		assert.Equal(t, mustParse(t, record[0]).UUID(), record[1])
+	}
+}
+
+func TestGenPinned(t *testing.T) {
+	args := []string{"gen", "-n", "3", "-time", "2024-01-01T00:00:00Z", "-seed", "42", "user"}
+	first, stderr, code := runCommand(t, "", args...)
+	require.Equal(t, 0, code, stderr)
+	second, _, _ := runCommand(t, "", args...)
+	assert.Equal(t, first, second, "pinned time and seed should be reproducible")
+
+	lines := strings.Fields(first)
+	assert.True(t, slices.IsSorted(lines))
+	for _, line := range lines {
+		created, ok := mustParse(t, line).Time()
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), created)
+	}
+}
+
+func TestGenErrors(t *testing.T) {
+	testdata := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"invalid prefix", []string{"gen", "User"}, 1, "prefix must contain only [a-z_]"},
+		{"invalid format", []string{"gen", "-format", "xml"}, 2, `invalid -format "xml"`},
+		{"invalid time", []string{"gen", "-time", "yesterday"}, 2, `invalid -time "yesterday"`},
+		{"negative count", []string{"gen", "-n", "-1"}, 2, "must not be negative"},
+		{"seed without time", []string{"gen", "-seed", "1"}, 2, "-seed requires -time"},
+		{"too many arguments", []string{"gen", "user", "order"}, 2, "too many arguments"},
+		{"unknown flag", []string{"gen", "-bogus"}, 2, "flag provided but not defined"},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			stdout, stderr, code := runCommand(t, "", td.args...)
+			assert.Equal(t, td.code, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, td.want)
+		})
+	}
+}
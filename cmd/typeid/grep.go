@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// runGrep implements "typeid grep".
+func runGrep(e *env, args []string) error {
+	fs := newFlagSet(e, "grep", "[file ...]",
+		"Finds the TypeIDs embedded in free-form text, such as logs, read from the given\n"+
+			"files or stdin, and prints those that match the filters, one per line. Exits\n"+
+			"with status 1 if no id matched.")
+	var prefixes stringsFlag
+	fs.Var(&prefixes, "prefix", "only print ids with this `prefix`; repeatable to allow several")
+	from := fs.String("from", "", "only print ids created at or after this RFC 3339 `timestamp`")
+	to := fs.String("to", "", "only print ids created before this RFC 3339 `timestamp`")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	g := &grepper{prefixes: prefixes, w: bufio.NewWriter(e.stdout)}
+	var err error
+	if g.from, err = parseTimeFlag(*from); err != nil {
+		return usageErrorf(fs, "invalid -from %q: %v", *from, err)
+	}
+	if g.to, err = parseTimeFlag(*to); err != nil {
+		return usageErrorf(fs, "invalid -to %q: %v", *to, err)
+	}
+
+	if fs.NArg() == 0 {
+		err = g.grep(e.stdin)
+	}
+	for _, name := range fs.Args() {
+		if err != nil {
+			break
+		}
+		err = g.grepFile(name, e.stdin)
+	}
+	if flushErr := g.w.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+	if g.matched == 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// parseTimeFlag parses an optional RFC 3339 timestamp.
+func parseTimeFlag(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// grepper prints the ids of streams that match its filters.
+type grepper struct {
+	prefixes []string  // Allowed prefixes; nil allows any prefix
+	from, to time.Time // Creation time range; the zero time leaves it open
+	w        *bufio.Writer
+	matched  int
+}
+
+// grepFile prints the matching ids of the named file, or stdin for "-".
+func (g *grepper) grepFile(name string, stdin io.Reader) error {
+	if name == "-" {
+		return g.grep(stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.grep(f)
+}
+
+// grep prints the matching ids of r.
+func (g *grepper) grep(r io.Reader) error {
+	for tid, err := range typeid.FindAllReader(r) {
+		if err != nil {
+			return err
+		}
+		if !g.match(tid) {
+			continue
+		}
+		g.matched++
+		if _, err := g.w.WriteString(tid.String() + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// match reports whether tid passes the filters. Ids without a creation time
+// never pass a time filter.
+func (g *grepper) match(tid typeid.TypeID) bool {
+	if g.prefixes != nil && !slices.Contains(g.prefixes, tid.Prefix()) {
+		return false
+	}
+	if g.from.IsZero() && g.to.IsZero() {
+		return true
+	}
+	t, ok := tid.Time()
+	if !ok {
+		return false
+	}
+	return (g.from.IsZero() || !t.Before(g.from)) && (g.to.IsZero() || t.Before(g.to))
+}
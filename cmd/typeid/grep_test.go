@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestGrep(t *testing.T) {
+	hour := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	before, err := typeid.MaxForTime("order", hour.Add(-time.Millisecond))
+	require.NoError(t, err)
+	first, err := typeid.MinForTime("order", hour)
+	require.NoError(t, err)
+	last, err := typeid.MaxForTime("user", hour.Add(time.Hour-time.Millisecond))
+	require.NoError(t, err)
+	after, err := typeid.MinForTime("order", hour.Add(time.Hour))
+	require.NoError(t, err)
+
+	input := "10:00 created order=" + first.String() + " by user " + last.String() + "\n" +
+		"09:59 [" + before.String() + "] and " + after.String() + ", not Order_" + testSuffix + "\n" +
+		"no prefix " + testSuffix + "\n"
+
+	stdout, stderr, code := runCommand(t, input, "grep")
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, first.String()+"\n"+last.String()+"\n"+before.String()+"\n"+after.String()+"\n"+testSuffix+"\n", stdout)
+
+	stdout, _, code = runCommand(t, input, "grep", "-prefix", "order")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, first.String()+"\n"+before.String()+"\n"+after.String()+"\n", stdout)
+
+	stdout, _, code = runCommand(t, input, "grep", "-prefix", "")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, testSuffix+"\n", stdout)
+
+	// The range is half-open, and ids without a creation time never match it
+	stdout, _, code = runCommand(t, input, "grep", "-from", "2024-01-01T10:00:00Z", "-to", "2024-01-01T11:00:00Z")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, first.String()+"\n"+last.String()+"\n", stdout)
+
+	stdout, _, code = runCommand(t, input, "grep", "-prefix", "order", "-from", "2024-01-01T10:00:00Z", "-to", "2024-01-01T11:00:00Z")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, first.String()+"\n", stdout)
+
+	stdout, _, code = runCommand(t, input, "grep", "-prefix", "invoice")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, stdout)
+}
+
+func TestGrepFiles(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(log, []byte("id=user_"+testSuffix+"\n"), 0o600))
+
+	stdout, _, code := runCommand(t, "order_"+testSuffix, "grep", log, "-")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "user_"+testSuffix+"\norder_"+testSuffix+"\n", stdout)
+
+	_, stderr, code := runCommand(t, "", "grep", filepath.Join(dir, "missing.log"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no such file")
+}
+
+func TestGrepUsage(t *testing.T) {
+	_, stderr, code := runCommand(t, "", "grep", "-from", "yesterday")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `invalid -from "yesterday"`)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+	"go.jetify.com/typeid/v2"
+)
+
+// runInspect implements "typeid inspect".
+func runInspect(e *env, args []string) error {
+	fs := newFlagSet(e, "inspect", "[id ...]",
+		"Decodes each id and prints its parts. Reads ids from stdin, one per line, if none are given.")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	ids := fs.Args()
+	if len(ids) == 0 {
+		scanner := bufio.NewScanner(e.stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				ids = append(ids, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	w := bufio.NewWriter(e.stdout)
+	failed, printed := false, false
+	for _, s := range ids {
+		tid, err := typeid.Parse(s)
+		if err != nil {
+			failed = true
+			w.Flush()
+			printParseError(e.stderr, s, err)
+			continue
+		}
+		if printed {
+			fmt.Fprintln(w)
+		}
+		printInspect(w, tid)
+		printed = true
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed {
+		return errFailed
+	}
+	return nil
+}
+
+// printInspect prints the parts of tid to w.
+func printInspect(w io.Writer, tid typeid.TypeID) {
+	uid := uuid.FromBytesOrNil(tid.Bytes())
+	fmt.Fprintf(w, "typeid:   %s\n", tid)
+	fmt.Fprintf(w, "prefix:   %s\n", orNone(tid.Prefix()))
+	fmt.Fprintf(w, "suffix:   %s\n", tid.Suffix())
+	fmt.Fprintf(w, "uuid:     %s\n", tid.UUID())
+	fmt.Fprintf(w, "version:  %d\n", uid.Version())
+	fmt.Fprintf(w, "variant:  %s\n", variantName(uid.Variant()))
+	if created, ok := tid.Time(); ok {
+		fmt.Fprintf(w, "time:     %s\n", created.Format(time.RFC3339Nano))
+		fmt.Fprintf(w, "local:    %s\n", created.Local().Format(time.RFC3339Nano))
+	} else {
+		fmt.Fprintf(w, "time:     (none, not a UUIDv7)\n")
+	}
+	fmt.Fprintf(w, "zero:     %t\n", !tid.HasSuffix())
+}
+
+// printParseError prints err to w. When the error reports the offending
+// character, the input is printed on its own line with a caret under it.
+func printParseError(w io.Writer, s string, err error) {
+	var located interface{ Offset() (int, bool) }
+	offset, ok := 0, false
+	if errors.As(err, &located) {
+		offset, ok = located.Offset()
+	}
+	if !ok {
+		fmt.Fprintf(w, "%q: %v\n", s, err)
+		return
+	}
+
+	// Line the caret up with the offending character, accounting for escaped
+	// and multi-byte characters in the quoted input.
+	offset = min(offset, len(s))
+	column := utf8.RuneCountInString(fmt.Sprintf("%q", s[:offset]))
+	fmt.Fprintf(w, "%q\n%s^ position %d: %v\n", s, strings.Repeat(" ", column-1), utf8.RuneCountInString(s[:offset])+1, err)
+}
+
+// variantName returns the name of a UUID variant.
+func variantName(v byte) string {
+	switch v {
+	case uuid.VariantNCS:
+		return "NCS (reserved)"
+	case uuid.VariantRFC9562:
+		return "RFC 9562"
+	case uuid.VariantMicrosoft:
+		return "Microsoft (reserved)"
+	default:
+		return "future (reserved)"
+	}
+}
+
+// orNone returns s, or "(none)" if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
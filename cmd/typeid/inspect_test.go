@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	stdout, stderr, code := runCommand(t, "", "inspect", "prefix_01h455vb4pex5vsknk084sn02q")
+	require.Equal(t, 0, code, stderr)
+
+	created := time.UnixMilli(0x01890a5dac96)
+	assert.Equal(t, ""+
+		"typeid:   prefix_01h455vb4pex5vsknk084sn02q\n"+
+		"prefix:   prefix\n"+
+		"suffix:   01h455vb4pex5vsknk084sn02q\n"+
+		"uuid:     01890a5d-ac96-774b-bcce-b302099a8057\n"+
+		"version:  7\n"+
+		"variant:  RFC 9562\n"+
+		"time:     2023-06-30T03:34:18.518Z\n"+
+		"local:    "+created.Local().Format(time.RFC3339Nano)+"\n"+
+		"zero:     false\n", stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestInspectNotV7(t *testing.T) {
+	stdout, _, code := runCommand(t, "", "inspect", "00000000000000000000000000")
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "prefix:   (none)\n")
+	assert.Contains(t, stdout, "version:  0\n")
+	assert.Contains(t, stdout, "time:     (none, not a UUIDv7)\n")
+	assert.Contains(t, stdout, "zero:     true\n")
+	assert.NotContains(t, stdout, "local:")
+}
+
+func TestInspectStdin(t *testing.T) {
+	stdin := "user_01h455vb4pex5vsknk084sn02q\n\n  order_01h455vb4pex5vsknk084sn02q  \n"
+	stdout, _, code := runCommand(t, "", "inspect")
+	require.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+
+	stdout, _, code = runCommand(t, stdin, "inspect")
+	require.Equal(t, 0, code)
+	assert.Contains(t, stdout, "prefix:   user\n")
+	assert.Contains(t, stdout, "zero:     false\n\ntypeid:   order_")
+}
+
+func TestInspectErrors(t *testing.T) {
+	testdata := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "invalid suffix character",
+			input: "user_01h455vb4pex5vsknk084sn0uq",
+			want: "\"user_01h455vb4pex5vsknk084sn0uq\"\n" +
+				"                              ^ position 30: typeid: invalid suffix encoding: illegal base32 data at offset 24\n",
+		},
+		{
+			name:  "invalid prefix character",
+			input: "préfix_01h455vb4pex5vsknk084sn02q",
+			want: "\"préfix_01h455vb4pex5vsknk084sn02q\"\n" +
+				"   ^ position 3: typeid: prefix must contain only [a-z_], found 'é' in \"préfix\"\n",
+		},
+		{
+			name:  "suffix too short",
+			input: "user_01h455",
+			want: "\"user_01h455\"\n" +
+				"            ^ position 12: typeid: suffix length must be 26, got 6\n",
+		},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			stdout, stderr, code := runCommand(t, "", "inspect", td.input)
+			assert.Equal(t, 1, code)
+			assert.Empty(t, stdout)
+			assert.Equal(t, td.want, stderr)
+		})
+	}
+
+	// Valid ids are still inspected when others fail
+	stdout, stderr, code := runCommand(t, "", "inspect", "user_01h455", "user_01h455vb4pex5vsknk084sn02q")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "prefix:   user\n")
+	assert.Contains(t, stderr, "position 12")
+}
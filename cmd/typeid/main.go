@@ -0,0 +1,145 @@
+// Command typeid generates, inspects and converts TypeIDs from the command line.
+//
+// Usage:
+//
+//	typeid <command> [flags] [arguments]
+//
+// Run "typeid help" for the list of commands, and "typeid <command> -h" for the
+// flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a typeid subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(env *env, args []string) error
+}
+
+// env holds the standard streams of a command, so commands can be tested
+// without touching the process' real streams.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// commands lists the subcommands in the order they are shown in the usage.
+var commands = []command{
+	{"gen", "generate new TypeIDs", runGen},
+	{"inspect", "decode ids and explain their parts", runInspect},
+	{"convert", "convert UUID and TypeID columns in CSV and JSON Lines files", runConvert},
+	{"validate", "validate ids line by line and report failures", runValidate},
+	{"grep", "find ids in logs and other free-form text", runGrep},
+	{"conformance", "check another implementation against the spec's test data", runConformance},
+}
+
+// errFailed is returned by commands that already reported their failure and
+// only need to set a non-zero exit status.
+var errFailed = errors.New("failed")
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+// run executes the command named by args[0] and returns the exit status.
+func run(args []string, e *env) int {
+	if len(args) == 0 {
+		usage(e.stderr)
+		return 2
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage(e.stdout)
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		err := cmd.run(e, args[1:])
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		case errors.Is(err, errFailed):
+			return 1
+		default:
+			fmt.Fprintf(e.stderr, "typeid %s: %v\n", name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(e.stderr, "typeid: unknown command %q\n", name)
+	usage(e.stderr)
+	return 2
+}
+
+// usage prints the list of commands to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: typeid <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "typeid <command> -h" for the flags of a command.`)
+}
+
+// errUsage is returned when a command is invoked with invalid flags or arguments.
+// The flag package has already printed the problem and the usage.
+var errUsage = errors.New("usage")
+
+// newFlagSet returns a FlagSet for the named command that writes its output to
+// the command's stderr.
+func newFlagSet(e *env, name, args, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet("typeid "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: typeid %s [flags] %s\n\n%s\n\nFlags:\n", name, args, description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args with fs, mapping flag errors to errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// usageErrorf reports a usage problem with fs and returns errUsage.
+func usageErrorf(fs *flag.FlagSet, format string, args ...any) error {
+	fmt.Fprintf(fs.Output(), format+"\n", args...)
+	fs.Usage()
+	return errUsage
+}
+
+// isFlagSet reports whether the named flag was given on the command line.
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
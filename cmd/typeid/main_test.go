@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+// runCommand runs the typeid command with the given stdin and arguments and
+// returns its output and exit status.
+func runCommand(t *testing.T, stdin string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, &env{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut})
+	return out.String(), errOut.String(), code
+}
+
+// mustParse parses s as a TypeID, failing the test if it's invalid.
+func mustParse(t *testing.T, s string) typeid.TypeID {
+	t.Helper()
+	tid, err := typeid.Parse(s)
+	require.NoError(t, err)
+	return tid
+}
+
+func TestRunUsage(t *testing.T) {
+	stdout, stderr, code := runCommand(t, "")
+	assert.Equal(t, 2, code)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Usage: typeid <command>")
+
+	stdout, _, code = runCommand(t, "", "help")
+	assert.Equal(t, 0, code)
+	for _, cmd := range commands {
+		assert.Contains(t, stdout, cmd.name)
+	}
+
+	_, stderr, code = runCommand(t, "", "bogus")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown command "bogus"`)
+}
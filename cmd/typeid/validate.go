@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// runValidate implements "typeid validate".
+func runValidate(e *env, args []string) error {
+	fs := newFlagSet(e, "validate", "[file ...]",
+		"Validates ids, one per line, read from the given files or stdin. Blank lines are\n"+
+			"skipped. Exits with status 1 if any id is invalid.")
+	var prefixes stringsFlag
+	fs.Var(&prefixes, "prefix", "require ids to have this `prefix`; repeatable to allow several")
+	jsonReport := fs.Bool("json", false, "print a JSON report instead of one line per failure")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	v := &validator{prefixes: prefixes}
+	if *jsonReport {
+		v.report = newJSONReport(e.stdout)
+	} else {
+		v.report = &textReport{w: bufio.NewWriter(e.stdout)}
+	}
+
+	// The report is closed even if reading fails, so it's always complete
+	err := v.validateAll(fs.Args(), e.stdin)
+	if closeErr := v.report.Close(v.checked, v.invalid); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if v.invalid > 0 {
+		return errFailed
+	}
+	return nil
+}
+
+// stringsFlag collects repeated string flags.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// failure describes an invalid id.
+type failure struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	ID     string `json:"id"`
+	Error  string `json:"error"`
+	Offset *int   `json:"offset,omitempty"` // Byte offset of the offending character, if known
+}
+
+// report receives validation failures as they are found.
+type report interface {
+	Failure(f failure) error
+	// Close finishes the report with the totals and flushes it.
+	Close(checked, invalid int) error
+}
+
+// validator validates streams of ids and keeps totals.
+type validator struct {
+	prefixes []string
+	report   report
+	checked  int
+	invalid  int
+}
+
+// validateAll validates the ids in the named files, or in stdin if there are
+// none.
+func (v *validator) validateAll(names []string, stdin io.Reader) error {
+	if len(names) == 0 {
+		return v.validate("-", stdin)
+	}
+	for _, name := range names {
+		if err := v.validateFile(name, stdin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFile validates the ids in the named file, or stdin for "-".
+func (v *validator) validateFile(name string, stdin io.Reader) error {
+	if name == "-" {
+		return v.validate(name, stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return v.validate(name, f)
+}
+
+// maxLineLen is the length of the lines that are reported as too long. Ids
+// are at most 90 bytes, so there's no need to keep such lines in memory.
+const maxLineLen = 4096
+
+// validate validates the ids in r, one per line.
+func (v *validator) validate(name string, r io.Reader) error {
+	br := bufio.NewReaderSize(r, maxLineLen)
+	for line := 1; ; line++ {
+		text, n, err := readLine(br)
+		if err == io.EOF && n == 0 {
+			return nil
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		id := strings.TrimSpace(text)
+		switch {
+		case n >= maxLineLen:
+			v.checked++
+			// Only the start of the line is reported, it's not an id anyway
+			if err := v.fail(name, line, id[:min(len(id), 32)]+"...", fmt.Errorf("line too long: %d bytes", n)); err != nil {
+				return err
+			}
+		case id != "":
+			v.checked++
+			if perr := v.parse(id); perr != nil {
+				if err := v.fail(name, line, id, perr); err != nil {
+					return err
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// readLine reads a line from r and returns its first maxLineLen bytes at
+// most, without the line ending, and its length in n. The rest of a longer
+// line is discarded. The error is io.EOF for a last line without a newline.
+func readLine(r *bufio.Reader) (text string, n int, err error) {
+	chunk, err := r.ReadSlice('\n')
+	text, n = string(chunk), len(chunk)
+	for err == bufio.ErrBufferFull {
+		chunk, err = r.ReadSlice('\n')
+		n += len(chunk)
+	}
+	if err == nil {
+		n-- // The newline
+	}
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "\n"), "\r")
+	return text, n, err
+}
+
+// fail records that id, found on the given line, is invalid.
+func (v *validator) fail(name string, line int, id string, err error) error {
+	v.invalid++
+	f := failure{File: name, Line: line, ID: id, Error: err.Error()}
+	var located interface{ Offset() (int, bool) }
+	if errors.As(err, &located) {
+		if offset, ok := located.Offset(); ok {
+			f.Offset = &offset
+		}
+	}
+	return v.report.Failure(f)
+}
+
+// parse validates id with the same rules as typeid.Parse, plus the prefixes.
+func (v *validator) parse(id string) error {
+	if len(v.prefixes) > 0 {
+		_, err := typeid.ParseWithPrefixes(id, v.prefixes...)
+		return err
+	}
+	_, err := typeid.Parse(id)
+	return err
+}
+
+// textReport prints one line per failure.
+type textReport struct {
+	w *bufio.Writer
+}
+
+func (r *textReport) Failure(f failure) error {
+	_, err := fmt.Fprintf(r.w, "%s:%d: %q: %s\n", f.File, f.Line, f.ID, f.Error)
+	return err
+}
+
+func (r *textReport) Close(checked, invalid int) error {
+	return r.w.Flush()
+}
+
+// jsonReport streams a JSON object of the form
+// {"failures": [...], "checked": n, "invalid": n}, so that failures don't need
+// to be kept in memory.
+type jsonReport struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newJSONReport(w io.Writer) *jsonReport {
+	return &jsonReport{w: bufio.NewWriter(w)}
+}
+
+func (r *jsonReport) Failure(f failure) error {
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if r.count == 0 {
+		r.w.WriteString(`{"failures":[` + "\n  ")
+	} else {
+		r.w.WriteString(",\n  ")
+	}
+	r.count++
+	_, err = r.w.Write(encoded)
+	return err
+}
+
+func (r *jsonReport) Close(checked, invalid int) error {
+	if r.count == 0 {
+		r.w.WriteString(`{"failures":[]`)
+	} else {
+		r.w.WriteString("\n]")
+	}
+	fmt.Fprintf(r.w, `,"checked":%d,"invalid":%d}`+"\n", checked, invalid)
+	return r.w.Flush()
+}
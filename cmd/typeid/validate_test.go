@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateValid(t *testing.T) {
+	input := "user_" + testSuffix + "\n\n  order_" + testSuffix + "  \n" + testSuffix
+	stdout, stderr, code := runCommand(t, input, "validate")
+	assert.Equal(t, 0, code, stderr)
+	assert.Empty(t, stdout)
+
+	stdout, _, code = runCommand(t, input, "validate", "-json", "-prefix", "user", "-prefix", "order", "-prefix", "")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, `{"failures":[],"checked":3,"invalid":0}`+"\n", stdout)
+}
+
+func TestValidateJSON(t *testing.T) {
+	input := "user_" + testSuffix + "\n" +
+		"user_" + testSuffix[:25] + "u\n" +
+		"\n" +
+		"order_" + testSuffix + "\n"
+
+	stdout, _, code := runCommand(t, input, "validate", "-json", "-prefix", "user")
+	assert.Equal(t, 1, code)
+
+	var report struct {
+		Failures []failure `json:"failures"`
+		Checked  int       `json:"checked"`
+		Invalid  int       `json:"invalid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 2, report.Invalid)
+	require.Len(t, report.Failures, 2)
+
+	offset := 30
+	assert.Equal(t, failure{
+		File:   "-",
+		Line:   2,
+		ID:     "user_" + testSuffix[:25] + "u",
+		Error:  "typeid: invalid suffix encoding: illegal base32 data at offset 25",
+		Offset: &offset,
+	}, report.Failures[0])
+	assert.Equal(t, failure{
+		File:  "-",
+		Line:  4,
+		ID:    "order_" + testSuffix,
+		Error: `typeid: expected prefix "user", got "order"`,
+	}, report.Failures[1])
+}
+
+func TestValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.txt")
+	bad := filepath.Join(dir, "bad.txt")
+	require.NoError(t, os.WriteFile(good, []byte("user_"+testSuffix+"\n"), 0o600))
+	require.NoError(t, os.WriteFile(bad, []byte("user_"+testSuffix+"\nUser_"+testSuffix+"\n"), 0o600))
+
+	stdout, _, code := runCommand(t, "", "validate", good, bad)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, bad+`:2: "User_`+testSuffix+`": typeid: prefix must contain only [a-z_], found 'U' in "User"`+"\n", stdout)
+
+	_, stderr, code := runCommand(t, "", "validate", filepath.Join(dir, "missing.txt"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no such file")
+}
+
+func TestValidateLongLines(t *testing.T) {
+	long := strings.Repeat("a", 70000)
+	input := "user_" + testSuffix + "\r\n" + long + "\n" + long + "\nUser_" + testSuffix
+	stdout, _, code := runCommand(t, input, "validate")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, `-:2: "`+long[:32]+`...": line too long: 70000 bytes`)
+	assert.Contains(t, stdout, "-:3: ")
+	assert.Contains(t, stdout, "-:4: \"User_")
+
+	stdout, _, code = runCommand(t, input, "validate", "-json")
+	assert.Equal(t, 1, code)
+	var report struct {
+		Failures []failure `json:"failures"`
+		Checked  int       `json:"checked"`
+		Invalid  int       `json:"invalid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+	assert.Equal(t, 4, report.Checked)
+	assert.Equal(t, 3, report.Invalid)
+}
+
+func TestValidateJSONAlwaysClosed(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.txt")
+	require.NoError(t, os.WriteFile(bad, []byte("User_"+testSuffix+"\n"), 0o600))
+
+	stdout, stderr, code := runCommand(t, "", "validate", "-json", bad, filepath.Join(dir, "missing.txt"))
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no such file")
+	assert.True(t, json.Valid([]byte(stdout)), stdout)
+	assert.Contains(t, stdout, `"checked":1,"invalid":1}`)
+}
@@ -0,0 +1,55 @@
+package typeid
+
+import "strings"
+
+// The base32 alphabet is in ascending ASCII order and the first suffix character
+// holds the top bits of the UUID, so comparing two suffixes as strings gives the
+// same result as comparing the UUID bytes they encode. The functions below rely
+// on that to order TypeIDs without decoding them.
+
+// Compare returns -1 if a sorts before b, 0 if they are equal and +1 if a sorts
+// after b. TypeIDs are ordered by prefix first and then by suffix, so ids of the
+// same type are grouped together and, for UUIDv7-based ids, sorted by creation
+// time within each group.
+//
+// Compare has the signature expected by slices.SortFunc and similar APIs:
+//
+//	slices.SortFunc(ids, typeid.Compare)
+func Compare(a, b TypeID) int {
+	// With prefixes of equal length the separators line up, so comparing the
+	// full values compares prefixes first and then suffixes.
+	if a.prefixLen == b.prefixLen {
+		return strings.Compare(a.value, b.value)
+	}
+	if c := strings.Compare(a.Prefix(), b.Prefix()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Suffix(), b.Suffix())
+}
+
+// CompareUUID is like Compare but ignores the prefixes and orders TypeIDs by
+// their UUID bytes only. For UUIDv7-based ids this sorts ids of different types
+// by creation time.
+func CompareUUID(a, b TypeID) int {
+	return strings.Compare(a.Suffix(), b.Suffix())
+}
+
+// Compare compares tid to other using Compare(tid, other).
+func (tid TypeID) Compare(other TypeID) int {
+	return Compare(tid, other)
+}
+
+// CompareUUID compares tid to other using CompareUUID(tid, other).
+func (tid TypeID) CompareUUID(other TypeID) int {
+	return CompareUUID(tid, other)
+}
+
+// Less reports whether tid sorts before other using Compare.
+func (tid TypeID) Less(other TypeID) bool {
+	return Compare(tid, other) < 0
+}
+
+// Compare compares id to other using Compare(id.TypeID(), other.TypeID()).
+func (id ID[P]) Compare(other ID[P]) int {
+	return Compare(id.tid, other.tid)
+}
@@ -0,0 +1,65 @@
+package typeid_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestCompare(t *testing.T) {
+	testdata := []struct {
+		name    string
+		a, b    string
+		compare int
+		uuid    int
+	}{
+		{"equal", "user_01h455vb4pex5vsknk084sn02q", "user_01h455vb4pex5vsknk084sn02q", 0, 0},
+		{"same prefix", "user_01h455vb4pex5vsknk084sn02q", "user_01h455vb4pex5vsknk084sn02r", -1, -1},
+		{"prefix first", "user_01h455vb4pex5vsknk084sn02q", "order_01h455vb4pex5vsknk084sn02r", 1, -1},
+		{"no prefix", "01h455vb4pex5vsknk084sn02q", "user_01h455vb4pex5vsknk084sn02q", -1, 0},
+		{"prefix of prefix", "user_01h455vb4pex5vsknk084sn02q", "user_admin_01h455vb4pex5vsknk084sn02q", -1, 0},
+		{"zero", "00000000000000000000000000", "01h455vb4pex5vsknk084sn02q", -1, -1},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			a, b := typeid.MustParse(td.a), typeid.MustParse(td.b)
+			assert.Equal(t, td.compare, typeid.Compare(a, b))
+			assert.Equal(t, -td.compare, typeid.Compare(b, a))
+			assert.Equal(t, td.compare, a.Compare(b))
+			assert.Equal(t, td.compare < 0, a.Less(b))
+
+			assert.Equal(t, td.uuid, typeid.CompareUUID(a, b))
+			assert.Equal(t, -td.uuid, b.CompareUUID(a))
+			assert.Equal(t, td.uuid, bytes.Compare(a.Bytes(), b.Bytes()))
+		})
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	ids := slices.Clone(testTypeIDs)
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	slices.SortFunc(ids, typeid.CompareUUID)
+	assert.True(t, slices.IsSortedFunc(ids, func(a, b typeid.TypeID) int {
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	}))
+
+	slices.SortFunc(ids, typeid.Compare)
+	for i := 1; i < len(ids); i++ {
+		prev, cur := ids[i-1], ids[i]
+		assert.LessOrEqual(t, prev.Prefix(), cur.Prefix())
+		if prev.Prefix() == cur.Prefix() {
+			assert.Less(t, prev.String(), cur.String())
+		}
+	}
+
+	typed := []TestID{typeid.MustGenerateID[TestPrefix](), typeid.MustGenerateID[TestPrefix]()}
+	slices.Reverse(typed)
+	slices.SortFunc(typed, TestID.Compare)
+	assert.Equal(t, -1, typed[0].Compare(typed[1]))
+}
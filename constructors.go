@@ -56,11 +56,12 @@ func Parse(s string) (TypeID, error) {
 	if suffix == "" {
 		return zeroID, &validationError{
 			Message: "suffix cannot be empty",
+			pos:     len(s) + 1,
 		}
 	}
 
 	// Validate suffix
-	if err := validateSuffix(suffix); err != nil {
+	if err := validateSuffix(suffix, len(s)-len(suffix)); err != nil {
 		return zeroID, err
 	}
 
@@ -82,6 +83,36 @@ func Parse(s string) (TypeID, error) {
 	return tid, nil
 }
 
+// ParseWithPrefix parses a TypeID like Parse and additionally checks that it
+// has the expected prefix. Pass an empty string to require an id without a prefix.
+// If the id is well-formed but has a different prefix, the returned error is a
+// *PrefixMismatchError.
+func ParseWithPrefix(s, prefix string) (TypeID, error) {
+	tid, err := Parse(s)
+	if err != nil {
+		return zeroID, err
+	}
+	if err := checkPrefix(tid, prefix); err != nil {
+		return zeroID, err
+	}
+	return tid, nil
+}
+
+// ParseWithPrefixes parses a TypeID like Parse and additionally checks that its
+// prefix is one of the allowed prefixes. If the id is well-formed but its prefix
+// is not allowed, the returned error is a *PrefixMismatchError. Without any
+// prefixes, no id is allowed: use Parse to accept every prefix.
+func ParseWithPrefixes(s string, prefixes ...string) (TypeID, error) {
+	tid, err := Parse(s)
+	if err != nil {
+		return zeroID, err
+	}
+	if err := checkPrefixes(tid, prefixes); err != nil {
+		return zeroID, err
+	}
+	return tid, nil
+}
+
 func split(id string) (string, string, error) {
 	index := strings.LastIndex(id, "_")
 	if index == -1 {
@@ -93,6 +124,7 @@ func split(id string) (string, string, error) {
 	if prefix == "" {
 		return "", "", &validationError{
 			Message: "prefix cannot be empty when separator \"_\" is present",
+			pos:     1,
 		}
 	}
 	return prefix, suffix, nil
@@ -2,14 +2,19 @@ package typeid
 
 import (
 	"encoding"
+	"encoding/gob"
+	"fmt"
 )
 
-// TODO: Define a standardized binary encoding for typeids in the spec
-// and use that to implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler
-
 var (
-	_ encoding.TextMarshaler   = (*TypeID)(nil)
-	_ encoding.TextUnmarshaler = (*TypeID)(nil)
+	_ encoding.TextMarshaler     = (*TypeID)(nil)
+	_ encoding.TextUnmarshaler   = (*TypeID)(nil)
+	_ encoding.TextAppender      = (*TypeID)(nil)
+	_ encoding.BinaryMarshaler   = (*TypeID)(nil)
+	_ encoding.BinaryUnmarshaler = (*TypeID)(nil)
+	_ encoding.BinaryAppender    = (*TypeID)(nil)
+	_ gob.GobEncoder             = (*TypeID)(nil)
+	_ gob.GobDecoder             = (*TypeID)(nil)
 )
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
@@ -37,3 +42,135 @@ func (tid TypeID) AppendText(dst []byte) ([]byte, error) {
 	}
 	return append(dst, tid.value...), nil
 }
+
+// The binary encoding of a TypeID is a compact, self-describing layout:
+//
+//	+--------+----------------------------+----------+
+//	| length | packed prefix              | UUID     |
+//	| 1 byte | ceil(5 * length / 8) bytes | 16 bytes |
+//	+--------+----------------------------+----------+
+//
+// The first byte holds the length of the prefix (0 to 63). The prefix follows,
+// with each character packed into 5 bits ('a' to 'z' map to 0 to 25 and '_' maps
+// to 26), most significant bit first, and the final byte padded with zero bits.
+// The 16 UUID bytes come last. For example "user_..." encodes to 20 bytes and an
+// id without a prefix encodes to 17 bytes.
+
+// prefixBits is the number of bits used to store each prefix character.
+const prefixBits = 5
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It encodes the TypeID using the compact binary layout described above.
+func (tid TypeID) MarshalBinary() ([]byte, error) {
+	return tid.AppendBinary(nil)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface. It appends the
+// binary representation of the TypeID to dst and returns the extended buffer.
+func (tid TypeID) AppendBinary(dst []byte) ([]byte, error) {
+	prefix := tid.Prefix()
+	dst = append(dst, byte(len(prefix)))
+
+	var acc uint32
+	var nbits uint
+	for i := 0; i < len(prefix); i++ {
+		acc = acc<<prefixBits | uint32(prefixCode(prefix[i]))
+		nbits += prefixBits
+		if nbits >= 8 {
+			nbits -= 8
+			dst = append(dst, byte(acc>>nbits))
+		}
+	}
+	if nbits > 0 {
+		dst = append(dst, byte(acc<<(8-nbits)))
+	}
+
+	uid := tid.uuidBytes()
+	return append(dst, uid[:]...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It decodes a TypeID from the binary layout produced by MarshalBinary.
+func (tid *TypeID) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return &validationError{
+			Message: "binary data cannot be empty",
+		}
+	}
+
+	prefixLen := int(data[0])
+	if prefixLen > 63 {
+		return &validationError{
+			Message: fmt.Sprintf("prefix length must be <= 63, got %d", prefixLen),
+		}
+	}
+	packedLen := (prefixLen*prefixBits + 7) / 8
+	if want := 1 + packedLen + 16; len(data) != want {
+		return &validationError{
+			Message: fmt.Sprintf("binary data must be %d bytes for a prefix of length %d, got %d", want, prefixLen, len(data)),
+		}
+	}
+
+	var buf [63]byte
+	prefix := buf[:prefixLen]
+	packed := data[1 : 1+packedLen]
+	var acc uint32
+	var nbits uint
+	for i := range prefix {
+		for nbits < prefixBits {
+			acc = acc<<8 | uint32(packed[0])
+			packed = packed[1:]
+			nbits += 8
+		}
+		nbits -= prefixBits
+		c, ok := prefixChar(byte(acc>>nbits) & (1<<prefixBits - 1))
+		if !ok {
+			return &validationError{
+				Message: fmt.Sprintf("invalid prefix encoding at character %d", i),
+			}
+		}
+		prefix[i] = c
+	}
+	if acc&(1<<nbits-1) != 0 {
+		return &validationError{
+			Message: "non-zero padding bits in prefix encoding",
+		}
+	}
+
+	parsed, err := FromBytes(string(prefix), data[1+packedLen:])
+	if err != nil {
+		return err
+	}
+	*tid = parsed
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface using the binary encoding.
+func (tid TypeID) GobEncode() ([]byte, error) {
+	return tid.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface using the binary encoding.
+func (tid *TypeID) GobDecode(data []byte) error {
+	return tid.UnmarshalBinary(data)
+}
+
+// prefixCode maps a valid prefix character to its 5-bit code.
+func prefixCode(c byte) byte {
+	if c == '_' {
+		return 26
+	}
+	return c - 'a'
+}
+
+// prefixChar maps a 5-bit code back to its prefix character.
+func prefixChar(code byte) (byte, bool) {
+	switch {
+	case code < 26:
+		return 'a' + code, true
+	case code == 26:
+		return '_', true
+	default:
+		return 0, false
+	}
+}
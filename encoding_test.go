@@ -1,8 +1,11 @@
 package typeid_test
 
 import (
+	"bytes"
 	_ "embed"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/goccy/go-yaml"
@@ -178,3 +181,104 @@ func TestJSONOmitZero(t *testing.T) {
 		})
 	}
 }
+
+func TestBinaryValid(t *testing.T) {
+	var testdata []ValidExample
+	err := yaml.Unmarshal(validEncodingYML, &testdata)
+	require.NoError(t, err)
+
+	for _, td := range testdata {
+		t.Run(td.Name, func(t *testing.T) {
+			tid := typeid.MustParse(td.Tid)
+
+			encoded, err := tid.MarshalBinary()
+			require.NoError(t, err)
+			assert.Len(t, encoded, 1+(5*len(td.Prefix)+7)/8+16)
+			assert.Equal(t, byte(len(td.Prefix)), encoded[0])
+			assert.Equal(t, tid.Bytes(), encoded[len(encoded)-16:])
+
+			var decoded typeid.TypeID
+			require.NoError(t, decoded.UnmarshalBinary(encoded))
+			assert.Equal(t, tid, decoded)
+			assert.Equal(t, td.Tid, decoded.String())
+
+			// AppendBinary appends to existing data without modifying it
+			appended, err := tid.AppendBinary([]byte("prefix:"))
+			require.NoError(t, err)
+			assert.Equal(t, append([]byte("prefix:"), encoded...), appended)
+		})
+	}
+}
+
+func TestBinaryLayout(t *testing.T) {
+	tid := typeid.MustParse("ab_z_01h455vb4pex5vsknk084sn02q")
+	encoded, err := tid.MarshalBinary()
+	require.NoError(t, err)
+
+	// 'a'=0 'b'=1 '_'=26 'z'=25 packed 5 bits each: 00000 00001 11010 11001 (+ 0000 padding)
+	assert.Equal(t, []byte{4, 0b00000000, 0b01110101, 0b10010000}, encoded[:4])
+	assert.Equal(t, tid.Bytes(), encoded[4:])
+
+	zero, err := typeid.TypeID{}.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 17), zero)
+}
+
+func TestBinaryInvalid(t *testing.T) {
+	uid := make([]byte, 16)
+	testdata := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"prefix too long", append([]byte{64}, make([]byte, 40+16)...)},
+		{"too short", []byte{0, 1, 2, 3}},
+		{"too long", append([]byte{0}, make([]byte, 17)...)},
+		{"missing prefix bytes", append([]byte{4}, uid...)},
+		{"invalid prefix code", append([]byte{1, 0b11111000}, uid...)},
+		{"non-zero padding", append([]byte{1, 0b00000001}, uid...)},
+		{"leading underscore", append([]byte{1, 0b11010000}, uid...)},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			var decoded typeid.TypeID
+			err := decoded.UnmarshalBinary(td.data)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	type Record struct {
+		ID     typeid.TypeID
+		Owner  TestID
+		Others []typeid.TypeID
+	}
+
+	var testdata []ValidExample
+	err := yaml.Unmarshal(validEncodingYML, &testdata)
+	require.NoError(t, err)
+
+	record := Record{Owner: typeid.MustGenerateID[TestPrefix]()}
+	for _, td := range testdata {
+		record.Others = append(record.Others, typeid.MustParse(td.Tid))
+	}
+	record.ID = record.Others[len(record.Others)-1]
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(record))
+
+	var decoded Record
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	assert.Equal(t, record, decoded)
+
+	// Typed ids check the prefix when decoding
+	buf.Reset()
+	require.NoError(t, gob.NewEncoder(&buf).Encode(typeid.MustGenerate("other")))
+	var typed TestID
+	err = gob.NewDecoder(&buf).Decode(&typed)
+	var mismatch *typeid.PrefixMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+}
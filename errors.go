@@ -4,12 +4,24 @@ import "fmt"
 
 // ErrValidation is a sentinel error for validation failures.
 // Use errors.Is(err, ErrValidation) to check if an error is a validation error.
+//
+// Validation errors caused by a specific character of the input also implement
+// interface{ Offset() (int, bool) }, which reports the byte offset of that
+// character.
 var ErrValidation error = &validationError{}
 
 // validationError represents errors that occur during TypeID validation
 type validationError struct {
 	Message string
 	Cause   error // Optional wrapped error (e.g., from base32)
+	pos     int   // 1-based position of the offending character, 0 if unknown
+}
+
+// Offset returns the 0-based byte offset of the character that caused the error
+// within the validated string. It returns false if the error isn't tied to a
+// single character.
+func (e *validationError) Offset() (int, bool) {
+	return e.pos - 1, e.pos > 0
 }
 
 // Error implements the error interface
@@ -29,3 +41,27 @@ func (e *validationError) Unwrap() error {
 func (e *validationError) Is(target error) bool {
 	return target == ErrValidation
 }
+
+// PrefixMismatchError is returned when a TypeID is well-formed but its prefix
+// is not the one that was expected.
+//
+// It matches ErrValidation with errors.Is, so existing error handling keeps
+// working. Use errors.As to recover the expected and actual prefixes.
+type PrefixMismatchError struct {
+	Expected string   // The prefix that was required, if exactly one was allowed
+	Allowed  []string // The set of allowed prefixes, always set
+	Actual   string   // The prefix that was found
+}
+
+// Error implements the error interface
+func (e *PrefixMismatchError) Error() string {
+	if e.Allowed == nil || len(e.Allowed) == 1 {
+		return fmt.Sprintf("typeid: expected prefix %q, got %q", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("typeid: expected one of prefixes %q, got %q", e.Allowed, e.Actual)
+}
+
+// Is implements error matching and returns true for ErrValidation
+func (e *PrefixMismatchError) Is(target error) bool {
+	return target == ErrValidation
+}
@@ -466,3 +466,75 @@ func TestFromUUIDErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefixMismatchError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *PrefixMismatchError
+		want string
+	}{
+		{
+			name: "single prefix",
+			err:  &PrefixMismatchError{Expected: "user", Actual: "order"},
+			want: `typeid: expected prefix "user", got "order"`,
+		},
+		{
+			name: "empty prefix",
+			err:  &PrefixMismatchError{Expected: "", Actual: "order"},
+			want: `typeid: expected prefix "", got "order"`,
+		},
+		{
+			name: "allowed prefixes",
+			err:  &PrefixMismatchError{Allowed: []string{"user", "team"}, Actual: "order"},
+			want: `typeid: expected one of prefixes ["user" "team"], got "order"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+			assert.True(t, errors.Is(tt.err, ErrValidation), "expected validation error")
+		})
+	}
+}
+
+func TestValidationErrorOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		offset int
+		ok     bool
+	}{
+		{"uppercase prefix", "Prefix_00000000000000000000000000", 0, true},
+		{"invalid prefix char", "pre.fix_00000000000000000000000000", 3, true},
+		{"non-ascii prefix", "préfix_00000000000000000000000000", 2, true},
+		{"prefix too long", "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijkl_00000000000000000000000000", 63, true},
+		{"prefix ends with underscore", "prefix__00000000000000000000000000", 6, true},
+		{"empty prefix with separator", "_00000000000000000000000000", 0, true},
+		{"empty suffix", "prefix_", 7, true},
+		{"suffix too short", "prefix_0000000000000000000000000", 32, true},
+		{"suffix too long", "prefix_000000000000000000000000000", 33, true},
+		{"suffix overflow", "prefix_80000000000000000000000000", 7, true},
+		{"invalid base32 char", "prefix_0000000000000000000000000u", 32, true},
+		{"suffix too long no prefix", "000000000000l00000000000000", 26, true},
+		{"invalid base32 char no prefix", "000000000000l0000000000000", 12, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			require.Error(t, err)
+
+			var located interface{ Offset() (int, bool) }
+			require.True(t, errors.As(err, &located), "validation errors should report an offset")
+			offset, ok := located.Offset()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.offset, offset)
+		})
+	}
+
+	t.Run("without position", func(t *testing.T) {
+		_, ok := (&validationError{Message: "no position"}).Offset()
+		assert.False(t, ok)
+	})
+}
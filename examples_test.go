@@ -3,7 +3,12 @@ package typeid_test
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"time"
 
 	"go.jetify.com/typeid/v2"
 )
@@ -150,3 +155,194 @@ func Example_nullableColumns() {
 	// Is valid: true
 	// Manager: user_00041061050r3gg28a1c60t3gf
 }
+
+type userPrefix struct{}
+
+func (userPrefix) Prefix() string { return "user" }
+
+// UserID is a TypeID that can only hold "user" ids.
+type UserID = typeid.ID[userPrefix]
+
+// ExampleParseID demonstrates using typed IDs so the compiler can tell entities apart
+func ExampleParseID() {
+	userID, err := typeid.ParseID[userPrefix]("user_00041061050r3gg28a1c60t3gf")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("User: %s\n", userID)
+
+	// IDs with another prefix are rejected
+	_, err = typeid.ParseID[userPrefix]("order_00041061050r3gg28a1c60t3gf")
+	fmt.Println(err)
+
+	// Output:
+	// User: user_00041061050r3gg28a1c60t3gf
+	// typeid: expected prefix "user", got "order"
+}
+
+// ExampleParseWithPrefix demonstrates validating the type of an id received from a client
+func ExampleParseWithPrefix() {
+	_, err := typeid.ParseWithPrefix("order_00041061050r3gg28a1c60t3gf", "user")
+
+	var mismatch *typeid.PrefixMismatchError
+	if errors.As(err, &mismatch) {
+		fmt.Printf("Wanted a %s id, got a %s id\n", mismatch.Expected, mismatch.Actual)
+	}
+	fmt.Printf("Is validation error: %v\n", errors.Is(err, typeid.ErrValidation))
+
+	// Output:
+	// Wanted a user id, got a order id
+	// Is validation error: true
+}
+
+// ExampleNewGenerator demonstrates generating reproducible ids for golden-file tests
+func ExampleNewGenerator() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := typeid.NewGenerator(
+		typeid.WithClock(func() time.Time { return now }),
+		typeid.WithEntropy(rand.NewChaCha8([32]byte{})),
+	)
+
+	// Ids from the same generator are strictly increasing
+	fmt.Println(gen.MustGenerate("user"))
+	fmt.Println(gen.MustGenerate("user"))
+	// Output:
+	// user_01hk153x00f63vxkkd6t5ar6kf
+	// user_01hk153x00f63vxkkd6t5ar6kg
+}
+
+// ExampleAsUUID demonstrates storing TypeIDs in a native uuid column
+func ExampleAsUUID() {
+	userID := typeid.MustParse("user_00041061050r3gg28a1c60t3gf")
+
+	// In real code, this would be passed to db.Exec()
+	value, err := typeid.AsUUID(&userID, "user").Value()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Stored: %v\n", value)
+
+	// In real code, this would be passed to sql.Row.Scan()
+	var scanned typeid.TypeID
+	err = typeid.AsUUID(&scanned, "user").Scan(value)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Retrieved: %s\n", scanned)
+
+	// Output:
+	// Stored: 00010203-0405-0607-0809-0a0b0c0d0e0f
+	// Retrieved: user_00041061050r3gg28a1c60t3gf
+}
+
+// ExampleNewRedactingHandler demonstrates keeping session ids out of logs
+func ExampleNewRedactingHandler() {
+	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		// Remove the timestamp so the output is stable
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := slog.New(typeid.NewRedactingHandler(handler, "session"))
+
+	userID := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	sessionID := typeid.MustParse("session_01h455vb4pex5vsknk084sn02q")
+	logger.Info("login", "user", userID, "session", sessionID)
+	// Output:
+	// level=INFO msg=login user.prefix=user user.suffix=01h455vb4pex5vsknk084sn02q user.time=2023-06-30T03:34:18.518Z session.prefix=session session.suffix=01h455vb4p**************** session.time=2023-06-30T03:34:18.518Z
+}
+
+// ExampleTypeID_Format demonstrates the formatting verbs supported by TypeID
+func ExampleTypeID_Format() {
+	tid := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+
+	fmt.Printf("%s\n", tid)
+	fmt.Printf("%v\n", tid)
+	fmt.Printf("%q\n", tid)
+	fmt.Printf("%x\n", tid)
+	fmt.Printf("%X\n", tid)
+	fmt.Printf("%+v\n", tid)
+	// Output:
+	// user_01h455vb4pex5vsknk084sn02q
+	// user_01h455vb4pex5vsknk084sn02q
+	// "user_01h455vb4pex5vsknk084sn02q"
+	// 01890a5dac96774bbcceb302099a8057
+	// 01890A5DAC96774BBCCEB302099A8057
+	// user_01h455vb4pex5vsknk084sn02q (prefix "user", uuid 01890a5d-ac96-774b-bcce-b302099a8057, time 2023-06-30T03:34:18.518Z)
+}
+
+// ExampleTypeID_Format_noTime demonstrates the verbose form of an id that isn't a UUIDv7
+func ExampleTypeID_Format_noTime() {
+	tid := typeid.MustParse("00041061050r3gg28a1c60t3gf")
+	fmt.Printf("%+v\n", tid)
+	// Output:
+	// 00041061050r3gg28a1c60t3gf (prefix "", uuid 00010203-0405-0607-0809-0a0b0c0d0e0f)
+}
+
+// ExampleObfuscator demonstrates hiding the creation time of ids shown to customers
+func ExampleObfuscator() {
+	// In real code, the secret would come from a secret manager
+	secret := []byte("0123456789abcdef")
+	obf, err := typeid.NewObfuscator(typeid.ObfuscationKey{ID: 1, Secret: secret})
+	if err != nil {
+		panic(err)
+	}
+
+	internal := typeid.MustParse("invoice_01h455vb4pex5vsknk084sn02q")
+	public, err := obf.Obfuscate(internal)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Public:", public)
+
+	revealed, err := obf.Reveal(public)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Internal:", revealed)
+	// Output:
+	// Public: invoice_07q41g3sncevx4hzf2wqefntfa
+	// Internal: invoice_01h455vb4pex5vsknk084sn02q
+}
+
+// ExampleSigner demonstrates issuing and checking invite links
+func ExampleSigner() {
+	// In real code, the key would come from a secret manager
+	signer, err := typeid.NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		panic(err)
+	}
+
+	invite := typeid.MustParse("invite_01h455vb4pex5vsknk084sn02q")
+	token := signer.Sign(invite)
+	fmt.Println("https://example.com/join/" + token)
+
+	verified, err := signer.Verify(token)
+	fmt.Println(verified, err)
+
+	_, err = signer.Verify("invite_01h455vb4pex5vsknk084sn02r" + token[len(invite.String()):])
+	fmt.Println(errors.Is(err, typeid.ErrInvalidSignature))
+	// Output:
+	// https://example.com/join/invite_01h455vb4pex5vsknk084sn02q.1esm8x5azt4d2yt4evx9mhxzw4
+	// invite_01h455vb4pex5vsknk084sn02q <nil>
+	// true
+}
+
+// ExampleParseWithCheck demonstrates catching typos in ids typed in by people
+func ExampleParseWithCheck() {
+	tid := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	fmt.Println(tid.StringWithCheck())
+
+	// A support agent mistypes a character
+	_, err := typeid.ParseWithCheck("user_01h455vb4pex5vsknk084sm02qc")
+	var checkErr *typeid.CheckError
+	if errors.As(err, &checkErr) && checkErr.Kind == typeid.CheckMismatch {
+		fmt.Println("Please check the id and try again")
+	}
+	// Output:
+	// user_01h455vb4pex5vsknk084sn02qc
+	// Please check the id and try again
+}
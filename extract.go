@@ -0,0 +1,141 @@
+package typeid
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// The functions in this file find TypeIDs embedded in free-form text such as
+// log files. Text is split into words made of ASCII letters, digits and
+// underscores, and every word that is a valid TypeID is reported. For example
+// "order=order_01h455vb4pex5vsknk084sn02q," contains one TypeID, while
+// "ORDER_01h455vb4pex5vsknk084sn02q" and "xorder_01h455vb4pex5vsknk084sn02qx"
+// contain none.
+
+// maxIDLen is the length of the longest valid TypeID: a 63 character prefix,
+// the separator and the suffix.
+const maxIDLen = 63 + 1 + 26
+
+// isWord reports whether a byte can be part of a word.
+var isWord = func() (table [256]bool) {
+	for c := '0'; c <= '9'; c++ {
+		table[c] = true
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		table[c] = true
+		table[c-'a'+'A'] = true
+	}
+	table['_'] = true
+	return table
+}()
+
+// ScanTypeIDs is a bufio.SplitFunc that returns each TypeID found in the input,
+// skipping all other text:
+//
+//	scanner := bufio.NewScanner(r)
+//	scanner.Split(typeid.ScanTypeIDs)
+//	for scanner.Scan() {
+//		tid, _ := typeid.Parse(scanner.Text())
+//	}
+//
+// The returned tokens are always valid TypeIDs. Words that are too long to be a
+// TypeID are skipped without being buffered in full.
+func ScanTypeIDs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	i := 0
+	for {
+		for i < len(data) && !isWord[data[i]] {
+			i++
+		}
+		start := i
+		for i < len(data) && isWord[data[i]] {
+			i++
+		}
+
+		if i == len(data) && !atEOF {
+			// The last word may continue in the next read. Ask for more data,
+			// and if the word is already too long to be a TypeID, drop all but
+			// enough of its tail that the next call still sees a word that is
+			// too long.
+			if i-start > maxIDLen {
+				return i - (maxIDLen + 1), nil, nil
+			}
+			return start, nil, nil
+		}
+		if start == i {
+			return i, nil, nil
+		}
+		if word := data[start:i]; isTypeID(word) {
+			return i, word, nil
+		}
+	}
+}
+
+// FindAll returns an iterator over the TypeIDs embedded in b, in the order
+// they appear.
+func FindAll(b []byte) iter.Seq[TypeID] {
+	return func(yield func(TypeID) bool) {
+		for len(b) > 0 {
+			advance, token, _ := ScanTypeIDs(b, true)
+			b = b[advance:]
+			if token == nil {
+				return
+			}
+			tid, err := Parse(string(token))
+			if err != nil {
+				// Unreachable: ScanTypeIDs only returns valid TypeIDs.
+				continue
+			}
+			if !yield(tid) {
+				return
+			}
+		}
+	}
+}
+
+// FindAllReader returns an iterator over the TypeIDs embedded in the text read
+// from r, in the order they appear. It reads r incrementally, so memory use
+// doesn't grow with the input. If reading fails, the error is yielded last.
+func FindAllReader(r io.Reader) iter.Seq2[TypeID, error] {
+	return func(yield func(TypeID, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Split(ScanTypeIDs)
+		for scanner.Scan() {
+			tid, err := Parse(scanner.Text())
+			if err != nil {
+				// Unreachable: ScanTypeIDs only returns valid TypeIDs.
+				continue
+			}
+			if !yield(tid, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(zeroID, err)
+		}
+	}
+}
+
+// isTypeID reports whether word is a valid TypeID without allocating. It
+// applies the same rules as Parse.
+func isTypeID(word []byte) bool {
+	if len(word) < 26 || len(word) > maxIDLen {
+		return false
+	}
+
+	suffix := word[len(word)-26:]
+	if violation, _ := checkSuffixRules(suffix); violation != noViolation {
+		return false
+	}
+	if len(word) == 26 {
+		return true
+	}
+
+	// A prefix must be followed by the separator
+	prefix := word[:len(word)-26]
+	if len(prefix) < 2 || prefix[len(prefix)-1] != '_' {
+		return false
+	}
+	violation, _ := checkPrefixRules(prefix[:len(prefix)-1])
+	return violation == noViolation
+}
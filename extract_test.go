@@ -0,0 +1,111 @@
+package typeid_test
+
+import (
+	"bufio"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "" +
+		"2024-01-01T00:00:00Z INFO order=order_01h455vb4pex5vsknk084sn02q user_id:user_01h455vb4pex5vsknk084sn02r\n" +
+		"no prefix (01h455vb4pex5vsknk084sn02s), ORDER_01h455vb4pex5vsknk084sn02q uppercase\n" +
+		"xorder_01h455vb4pex5vsknk084sn02qx too long, order_01h455vb4pex5vsknk084sn0 too short\n" +
+		"order_81h455vb4pex5vsknk084sn02q overflow, _01h455vb4pex5vsknk084sn02q empty prefix\n" +
+		`{"id":"a_b_01h455vb4pex5vsknk084sn02t"}` + "\n" +
+		"end user_01h455vb4pex5vsknk084sn02v"
+
+	expected := []string{
+		"order_01h455vb4pex5vsknk084sn02q",
+		"user_01h455vb4pex5vsknk084sn02r",
+		"01h455vb4pex5vsknk084sn02s",
+		"a_b_01h455vb4pex5vsknk084sn02t",
+		"user_01h455vb4pex5vsknk084sn02v",
+	}
+
+	var found []string
+	for tid := range typeid.FindAll([]byte(text)) {
+		found = append(found, tid.String())
+	}
+	assert.Equal(t, expected, found)
+
+	// Reading one byte at a time splits words across reads
+	found = nil
+	for tid, err := range typeid.FindAllReader(iotest.OneByteReader(strings.NewReader(text))) {
+		require.NoError(t, err)
+		found = append(found, tid.String())
+	}
+	assert.Equal(t, expected, found)
+}
+
+func TestFindAllStopsEarly(t *testing.T) {
+	text := []byte("a_01h455vb4pex5vsknk084sn02q b_01h455vb4pex5vsknk084sn02q c_01h455vb4pex5vsknk084sn02q")
+	for tid := range typeid.FindAll(text) {
+		assert.Equal(t, "a", tid.Prefix())
+		break
+	}
+	for tid := range typeid.FindAllReader(strings.NewReader(string(text))) {
+		assert.Equal(t, "a", tid.Prefix())
+		break
+	}
+}
+
+func TestFindAllReaderError(t *testing.T) {
+	r := iotest.TimeoutReader(strings.NewReader("a_01h455vb4pex5vsknk084sn02q "))
+	var ids []typeid.TypeID
+	var errs []error
+	for tid, err := range typeid.FindAllReader(r) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, tid)
+	}
+	assert.Len(t, ids, 1)
+	require.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], iotest.ErrTimeout))
+}
+
+func TestScanTypeIDsLongWords(t *testing.T) {
+	// A TypeID at the end of a word longer than the scanner's buffer isn't reported
+	long := strings.Repeat("a", 10000) + "_01h455vb4pex5vsknk084sn02q"
+	text := long + " b_01h455vb4pex5vsknk084sn02q"
+
+	scanner := bufio.NewScanner(iotest.HalfReader(strings.NewReader(text)))
+	scanner.Buffer(make([]byte, 256), 256)
+	scanner.Split(typeid.ScanTypeIDs)
+
+	var found []string
+	for scanner.Scan() {
+		found = append(found, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"b_01h455vb4pex5vsknk084sn02q"}, found)
+}
+
+// TestFindAllTestdata checks that the scanner accepts exactly what Parse accepts.
+func TestFindAllTestdata(t *testing.T) {
+	var valid []ValidExample
+	require.NoError(t, yaml.Unmarshal(validYML, &valid))
+	for _, td := range valid {
+		found := slices.Collect(typeid.FindAll([]byte(" " + td.Tid + " ")))
+		assert.Equal(t, []typeid.TypeID{typeid.MustParse(td.Tid)}, found, td.Name)
+	}
+
+	var invalid []InvalidExample
+	require.NoError(t, yaml.Unmarshal(invalidYML, &invalid))
+	for _, td := range invalid {
+		for tid := range typeid.FindAll([]byte(td.Tid)) {
+			// Parts of an invalid id may be valid ids, but never the whole id
+			assert.NotEqual(t, td.Tid, tid.String(), td.Name)
+		}
+	}
+}
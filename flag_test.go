@@ -0,0 +1,118 @@
+package typeid_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+// newTestFlagSet returns a FlagSet that doesn't print errors.
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestVar(t *testing.T) {
+	var user, other typeid.TypeID
+	fs := newTestFlagSet()
+	typeid.Var(fs, &user, "user", "user id", "user")
+	typeid.Var(fs, &other, "other", "any id")
+
+	err := fs.Parse([]string{"-user", "user_01h455vb4pex5vsknk084sn02q", "-other", "order_01h455vb4pex5vsknk084sn02q"})
+	require.NoError(t, err)
+	assert.Equal(t, typeid.MustParse("user_01h455vb4pex5vsknk084sn02q"), user)
+	assert.Equal(t, typeid.MustParse("order_01h455vb4pex5vsknk084sn02q"), other)
+	assert.Equal(t, user, fs.Lookup("user").Value.(flag.Getter).Get())
+}
+
+func TestVarErrors(t *testing.T) {
+	testdata := []struct {
+		name     string
+		arg      string
+		expected string
+	}{
+		{"wrong prefix", "order_01h455vb4pex5vsknk084sn02q", `invalid value "order_01h455vb4pex5vsknk084sn02q" for flag -user: typeid: expected one of prefixes ["user" "admin"], got "order"`},
+		{"invalid", "user_01h455vb4pex5vsknk084sn02", `invalid value "user_01h455vb4pex5vsknk084sn02" for flag -user: typeid: suffix length must be 26, got 25`},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			var user typeid.TypeID
+			fs := newTestFlagSet()
+			typeid.Var(fs, &user, "user", "user id", "user", "admin")
+
+			err := fs.Parse([]string{"-user", td.arg})
+			assert.EqualError(t, err, td.expected)
+			assert.True(t, user.IsZero())
+
+			// The flag package only keeps the message, Set returns the error itself
+			err = fs.Lookup("user").Value.Set(td.arg)
+			assert.True(t, errors.Is(err, typeid.ErrValidation))
+		})
+	}
+}
+
+func TestVarDefaults(t *testing.T) {
+	var buf strings.Builder
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&buf)
+
+	var unset typeid.TypeID
+	preset := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	var list []typeid.TypeID
+	typeid.Var(fs, &unset, "unset", "no default")
+	typeid.Var(fs, &preset, "preset", "with a default")
+	typeid.SliceVar(fs, &list, "list", "a list")
+	fs.PrintDefaults()
+
+	assert.Equal(t, "  -list value\n"+
+		"    \ta list\n"+
+		"  -preset value\n"+
+		"    \twith a default (default user_01h455vb4pex5vsknk084sn02q)\n"+
+		"  -unset value\n"+
+		"    \tno default\n", buf.String())
+}
+
+func TestSliceVar(t *testing.T) {
+	a := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	b := typeid.MustParse("user_01h455vb4pex5vsknk084sn02r")
+	c := typeid.MustParse("user_01h455vb4pex5vsknk084sn02s")
+
+	// The default is replaced by the first value
+	users := []typeid.TypeID{c}
+	fs := newTestFlagSet()
+	typeid.SliceVar(fs, &users, "user", "user ids", "user")
+
+	err := fs.Parse([]string{"-user", a.String() + ", " + b.String(), "-user", c.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []typeid.TypeID{a, b, c}, users)
+	assert.Equal(t, a.String()+","+b.String()+","+c.String(), fs.Lookup("user").Value.String())
+
+	// Invalid lists are rejected as a whole
+	err = fs.Set("user", b.String()+",order_01h455vb4pex5vsknk084sn02q")
+	var mismatch *typeid.PrefixMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+	err = fs.Set("user", b.String()+",")
+	assert.True(t, errors.Is(err, typeid.ErrValidation))
+	assert.Equal(t, []typeid.TypeID{a, b, c}, users)
+}
+
+func TestTypedIDFlag(t *testing.T) {
+	// Typed IDs work with TextVar and check their prefix
+	var id TestID
+	fs := newTestFlagSet()
+	fs.TextVar(&id, "id", TestID{}, "test id")
+
+	require.NoError(t, fs.Parse([]string{"-id", "test_01h455vb4pex5vsknk084sn02q"}))
+	assert.Equal(t, "test_01h455vb4pex5vsknk084sn02q", id.String())
+
+	err := fs.Parse([]string{"-id", "user_01h455vb4pex5vsknk084sn02q"})
+	assert.EqualError(t, err, `invalid value "user_01h455vb4pex5vsknk084sn02q" for flag -id: typeid: expected prefix "test", got "user"`)
+}
@@ -0,0 +1,59 @@
+package typeid
+
+import (
+	"fmt"
+	"time"
+)
+
+var _ fmt.Formatter = TypeID{}
+
+// Format implements the fmt.Formatter interface. It supports the verbs:
+//
+//	%s, %v  the canonical form, like String
+//	%q      the canonical form, double-quoted; %#q uses backquotes
+//	%#v     the canonical form, double-quoted, as in Go syntax
+//	%x, %X  the UUID as 32 lower or upper case hex digits
+//	%+v     the canonical form followed by its prefix, UUID and creation time
+//
+// Width, precision and the other flags apply as they do to strings.
+func (tid TypeID) Format(f fmt.State, verb rune) {
+	format(f, verb, tid, tid)
+}
+
+// Format implements the fmt.Formatter interface, see TypeID.Format.
+func (id ID[P]) Format(f fmt.State, verb rune) {
+	format(f, verb, id.tid, id)
+}
+
+// format formats tid for the verb. The value being formatted is only used to
+// report unsupported verbs, the way fmt does.
+func format(f fmt.State, verb rune, tid TypeID, value any) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, fmt.FormatString(f, 's'), verbose(tid))
+			return
+		}
+		if f.Flag('#') {
+			fmt.Fprintf(f, "%q", tid.String())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), tid.String())
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), tid.String())
+	case 'x', 'X':
+		uid := tid.uuidBytes()
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uid[:])
+	default:
+		fmt.Fprintf(f, "%%!%c(%T=%s)", verb, value, tid.String())
+	}
+}
+
+// verbose returns the %+v form of tid.
+func verbose(tid TypeID) string {
+	s := fmt.Sprintf("%s (prefix %q, uuid %s", tid.String(), tid.Prefix(), tid.UUID())
+	if t, ok := tid.Time(); ok {
+		s += ", time " + t.Format(time.RFC3339Nano)
+	}
+	return s + ")"
+}
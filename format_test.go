@@ -0,0 +1,47 @@
+package typeid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestFormat(t *testing.T) {
+	tid := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	id, err := typeid.FromTypeID[TestPrefix](typeid.MustParse("test_01h455vb4pex5vsknk084sn02q"))
+	require.NoError(t, err)
+
+	testdata := []struct {
+		format   string
+		value    any
+		expected string
+	}{
+		{"%s", tid, "user_01h455vb4pex5vsknk084sn02q"},
+		{"%v", &tid, "user_01h455vb4pex5vsknk084sn02q"},
+		{"%35s|", tid, "    user_01h455vb4pex5vsknk084sn02q|"},
+		{"%-35v|", tid, "user_01h455vb4pex5vsknk084sn02q    |"},
+		{"%.4s", tid, "user"},
+		{"%q", tid, `"user_01h455vb4pex5vsknk084sn02q"`},
+		{"%#q", tid, "`user_01h455vb4pex5vsknk084sn02q`"},
+		{"%#v", tid, `"user_01h455vb4pex5vsknk084sn02q"`},
+		{"%x", tid, "01890a5dac96774bbcceb302099a8057"},
+		{"%X", tid, "01890A5DAC96774BBCCEB302099A8057"},
+		{"%#x", tid, "0x01890a5dac96774bbcceb302099a8057"},
+		{"%d", tid, "%!d(typeid.TypeID=user_01h455vb4pex5vsknk084sn02q)"},
+		{"%v", []typeid.TypeID{tid, {}}, "[user_01h455vb4pex5vsknk084sn02q 00000000000000000000000000]"},
+		{"%x", typeid.TypeID{}, "00000000000000000000000000000000"},
+		{"%+v", typeid.TypeID{}, `00000000000000000000000000 (prefix "", uuid 00000000-0000-0000-0000-000000000000)`},
+
+		{"%s", id, "test_01h455vb4pex5vsknk084sn02q"},
+		{"%x", id, "01890a5dac96774bbcceb302099a8057"},
+		{"%+v", id, `test_01h455vb4pex5vsknk084sn02q (prefix "test", uuid 01890a5d-ac96-774b-bcce-b302099a8057, time 2023-06-30T03:34:18.518Z)`},
+		{"%d", id, "%!d(typeid.ID[go.jetify.com/typeid/v2_test.TestPrefix]=test_01h455vb4pex5vsknk084sn02q)"},
+	}
+
+	for _, td := range testdata {
+		assert.Equal(t, td.expected, fmt.Sprintf(td.format, td.value), td.format)
+	}
+}
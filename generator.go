@@ -0,0 +1,212 @@
+package typeid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.jetify.com/typeid/v2/base32"
+)
+
+// Generator generates TypeIDs whose suffixes are strictly increasing, even when
+// many ids are generated within the same millisecond.
+//
+// The package-level Generate function makes no ordering guarantees for ids
+// created in the same millisecond. A Generator instead keeps track of the last
+// UUIDv7 it produced and, as allowed by RFC 9562 (section 6.2, method 2), treats
+// the 74 random bits as a counter that is incremented for each new id in the
+// same millisecond:
+//
+//   - When the clock moves forward, the random bits are drawn fresh.
+//   - When the clock stays the same or moves backwards, the previous timestamp is
+//     reused and the random bits are incremented by one.
+//   - When the random bits overflow, the timestamp is advanced by one millisecond
+//     and the random bits are drawn fresh.
+//
+// As a result, every id returned by a Generator sorts after the previous one,
+// both as a UUID and as a base32 suffix. Ordering is only guaranteed among the
+// ids of one Generator. A Generator is safe for concurrent use; create one with
+// NewGenerator, the zero value is not ready for use.
+type Generator struct {
+	now     func() time.Time
+	entropy io.Reader
+
+	mu      sync.Mutex
+	started bool // Whether last holds a previously generated UUID
+	lastMs  uint64
+	last    [16]byte
+}
+
+const (
+	maxRandA = 1<<12 - 1 // rand_a holds 12 random bits
+	maxRandB = 1<<62 - 1 // rand_b holds 62 random bits
+)
+
+// IDGenerator is implemented by types that generate TypeIDs. Services can depend
+// on an IDGenerator instead of calling Generate directly, so tests can substitute
+// a deterministic Generator.
+type IDGenerator interface {
+	Generate(prefix string) (TypeID, error)
+}
+
+// GeneratorFunc adapts an ordinary function to the IDGenerator interface.
+// For example, GeneratorFunc(Generate) uses the package-level Generate function.
+type GeneratorFunc func(prefix string) (TypeID, error)
+
+// Generate calls f(prefix).
+func (f GeneratorFunc) Generate(prefix string) (TypeID, error) {
+	return f(prefix)
+}
+
+var (
+	_ IDGenerator = (*Generator)(nil)
+	_ IDGenerator = GeneratorFunc(nil)
+)
+
+// GeneratorOption configures a Generator created by NewGenerator.
+type GeneratorOption func(*Generator)
+
+// WithClock sets the function used to read the current time. It defaults to
+// time.Now. Only millisecond precision is used.
+func WithClock(now func() time.Time) GeneratorOption {
+	return func(g *Generator) {
+		g.now = now
+	}
+}
+
+// WithEntropy sets the source of the random bits. It defaults to crypto/rand.Reader.
+//
+// Combined with WithClock and a seeded reader (such as math/rand/v2's ChaCha8),
+// it makes a Generator produce the exact same sequence of ids on every run, which
+// is useful for golden-file tests. Don't use a predictable reader in production.
+// Reads are serialized, so the reader doesn't need to be safe for concurrent use.
+func WithEntropy(r io.Reader) GeneratorOption {
+	return func(g *Generator) {
+		g.entropy = r
+	}
+}
+
+// NewGenerator returns a Generator configured by the given options. By default
+// it uses the system clock and a cryptographically secure source of randomness.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		now:     time.Now,
+		entropy: rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Generate returns a new TypeID with the given prefix and a suffix that sorts
+// after every suffix previously returned by this Generator. It returns a
+// validation error if the clock is before the Unix epoch or beyond the 48-bit
+// UUIDv7 timestamp. If you want to create an id without a prefix, pass an empty string.
+func (g *Generator) Generate(prefix string) (TypeID, error) {
+	// Validate prefix early
+	if err := validatePrefix(prefix); err != nil {
+		return zeroID, err
+	}
+
+	uid, err := g.next()
+	if err != nil {
+		return zeroID, err
+	}
+
+	// Use stack buffer for base32 encoding to avoid allocation
+	var suffixBuf [26]byte
+	base32.Encode(suffixBuf[:], uid)
+
+	return newTypeID(prefix, suffixBuf), nil
+}
+
+// MustGenerate returns a new TypeID like Generate.
+// It panics if the prefix is invalid. Use Generate() if you need error handling.
+func (g *Generator) MustGenerate(prefix string) TypeID {
+	tid, err := g.Generate(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return tid
+}
+
+// next returns the next UUIDv7 in the sequence. It returns a validation error
+// if the clock is outside the UUIDv7 timestamp range.
+func (g *Generator) next() ([16]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	if ms := now.UnixMilli(); ms < 0 || ms > maxTimestamp {
+		return [16]byte{}, &validationError{
+			Message: fmt.Sprintf("time %v is outside the UUIDv7 timestamp range", now),
+		}
+	}
+	ms := uint64(now.UnixMilli())
+
+	if g.started && ms <= g.lastMs {
+		if g.increment() {
+			return g.last, nil
+		}
+		// The random bits overflowed: borrow the next millisecond.
+		if g.lastMs == maxTimestamp {
+			return [16]byte{}, &validationError{
+				Message: "no ids left after the largest UUIDv7 timestamp",
+			}
+		}
+		ms = g.lastMs + 1
+	}
+
+	var uid [16]byte
+	if _, err := io.ReadFull(g.entropy, uid[6:]); err != nil {
+		return [16]byte{}, err
+	}
+	putTimestamp(&uid, ms)
+	setVersion7(&uid)
+
+	g.started = true
+	g.lastMs = ms
+	g.last = uid
+	return uid, nil
+}
+
+// increment adds one to the 74 random bits of the last UUID, leaving the
+// version and variant untouched. It returns false if the random bits overflow.
+func (g *Generator) increment() bool {
+	randA := uint16(g.last[6]&0x0f)<<8 | uint16(g.last[7])
+	randB := binary.BigEndian.Uint64(g.last[8:]) & maxRandB
+
+	if randB < maxRandB {
+		randB++
+	} else if randA < maxRandA {
+		randB = 0
+		randA++
+	} else {
+		return false
+	}
+
+	g.last[6] = 0x70 | byte(randA>>8)
+	g.last[7] = byte(randA)
+	binary.BigEndian.PutUint64(g.last[8:], randB|0x80<<56)
+	return true
+}
+
+// putTimestamp writes ms as the 48-bit big-endian timestamp of a UUIDv7.
+func putTimestamp(uid *[16]byte, ms uint64) {
+	uid[0] = byte(ms >> 40)
+	uid[1] = byte(ms >> 32)
+	uid[2] = byte(ms >> 24)
+	uid[3] = byte(ms >> 16)
+	uid[4] = byte(ms >> 8)
+	uid[5] = byte(ms)
+}
+
+// setVersion7 sets the version and variant bits of a UUIDv7.
+func setVersion7(uid *[16]byte) {
+	uid[6] = (uid[6] & 0x0f) | 0x70 // Version 7
+	uid[8] = (uid[8] & 0x3f) | 0x80 // Variant RFC 9562
+}
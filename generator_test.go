@@ -0,0 +1,213 @@
+package typeid
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedReader is an entropy source that always returns the same byte.
+type fixedReader byte
+
+func (r fixedReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+// testGenerator returns a Generator whose clock is controlled by the returned pointer.
+func testGenerator(entropy fixedReader) (*Generator, *time.Time) {
+	now := time.UnixMilli(1700000000000)
+	g := NewGenerator(
+		WithClock(func() time.Time { return now }),
+		WithEntropy(entropy),
+	)
+	return g, &now
+}
+
+func TestGeneratorStrictlyIncreasing(t *testing.T) {
+	g := NewGenerator()
+	prev := g.MustGenerate("test")
+	for i := 0; i < 10000; i++ {
+		tid := g.MustGenerate("test")
+		require.Less(t, prev.String(), tid.String(), "ids must be strictly increasing")
+		require.Equal(t, -1, bytes.Compare(prev.Bytes(), tid.Bytes()), "uuids must be strictly increasing")
+		prev = tid
+	}
+}
+
+func TestGeneratorConcurrent(t *testing.T) {
+	g := NewGenerator()
+	const workers, perWorker = 8, 1000
+
+	var wg sync.WaitGroup
+	results := make([][]TypeID, workers)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				results[w] = append(results[w], g.MustGenerate(""))
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[TypeID]bool)
+	for _, ids := range results {
+		// Each goroutine observes a strictly increasing sequence
+		assert.True(t, slices.IsSortedFunc(ids, func(a, b TypeID) int {
+			return bytes.Compare(a.Bytes(), b.Bytes())
+		}))
+		for _, tid := range ids {
+			assert.False(t, seen[tid], "duplicate id %s", tid)
+			seen[tid] = true
+		}
+	}
+	assert.Len(t, seen, workers*perWorker)
+}
+
+func TestGeneratorSameMillisecond(t *testing.T) {
+	g, _ := testGenerator(0)
+
+	first := g.MustGenerate("test")
+	second := g.MustGenerate("test")
+	assert.Equal(t, "test_01hf7yat00e008000000000000", first.String())
+	assert.Equal(t, "test_01hf7yat00e008000000000001", second.String())
+
+	uid := uuid.FromBytesOrNil(second.Bytes())
+	assert.Equal(t, byte(7), uid.Version())
+	assert.Equal(t, uuid.VariantRFC9562, uid.Variant())
+}
+
+func TestGeneratorClockBackwards(t *testing.T) {
+	g, now := testGenerator(0)
+
+	first := g.MustGenerate("")
+	*now = now.Add(-time.Second)
+	second := g.MustGenerate("")
+
+	// The timestamp of the last id is reused until the clock catches up
+	assert.Equal(t, first.Bytes()[:6], second.Bytes()[:6])
+	assert.Less(t, first.String(), second.String())
+
+	*now = now.Add(2 * time.Second)
+	third := g.MustGenerate("")
+	assert.Less(t, second.String(), third.String())
+	assert.NotEqual(t, second.Bytes()[:6], third.Bytes()[:6])
+}
+
+func TestGeneratorOverflow(t *testing.T) {
+	g, _ := testGenerator(0xff)
+
+	// All random bits are set, so the next id in the same millisecond overflows
+	first := g.MustGenerate("")
+	second := g.MustGenerate("")
+
+	assert.Equal(t, "01hf7yat00fzzvzzzzzzzzzzzz", first.String())
+	assert.Equal(t, "01hf7yat01fzzvzzzzzzzzzzzz", second.String())
+	assert.Less(t, first.String(), second.String())
+}
+
+func TestGeneratorEpoch(t *testing.T) {
+	g := NewGenerator(
+		WithClock(func() time.Time { return time.UnixMilli(0) }),
+		WithEntropy(fixedReader(0x11)),
+	)
+
+	// The first id reads entropy even though its timestamp is zero
+	first := g.MustGenerate("x")
+	second := g.MustGenerate("x")
+	assert.Equal(t, "x_0000000000e48s248h248h248h", first.String())
+	assert.Equal(t, "x_0000000000e48s248h248h248j", second.String())
+}
+
+func TestGeneratorClockOutOfRange(t *testing.T) {
+	for _, now := range []time.Time{
+		time.UnixMilli(-5),
+		time.UnixMilli(maxTimestamp + 1),
+	} {
+		g := NewGenerator(WithClock(func() time.Time { return now }))
+		_, err := g.Generate("x")
+		assert.ErrorIs(t, err, ErrValidation, "%v", now)
+		assert.False(t, g.started, "a failed call must not change the state")
+	}
+}
+
+func TestGeneratorLastTimestamp(t *testing.T) {
+	g := NewGenerator(
+		WithClock(func() time.Time { return time.UnixMilli(maxTimestamp) }),
+		WithEntropy(fixedReader(0xff)),
+	)
+	last := g.MustGenerate("")
+	assert.Equal(t, "7zzzzzzzzzfzzvzzzzzzzzzzzz", last.String())
+
+	// The random bits overflow and there's no next millisecond to borrow
+	_, err := g.Generate("")
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestGeneratorIncrementCarry(t *testing.T) {
+	g, _ := testGenerator(0)
+	g.MustGenerate("")
+
+	// Set rand_b to its maximum so the increment carries into rand_a
+	g.last[8] = 0xbf
+	for i := 9; i < 16; i++ {
+		g.last[i] = 0xff
+	}
+	before := g.last
+
+	tid := g.MustGenerate("")
+	assert.Equal(t, -1, bytes.Compare(before[:], tid.Bytes()))
+	assert.Equal(t, []byte{0x70, 0x01, 0x80, 0, 0, 0, 0, 0, 0, 0}, tid.Bytes()[6:])
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	g := NewGenerator()
+	_, err := g.Generate("INVALID")
+	assert.True(t, errors.Is(err, ErrValidation), "expected validation error")
+	assert.Panics(t, func() { g.MustGenerate("INVALID") })
+
+	g = NewGenerator(WithEntropy(bytes.NewReader(nil)))
+	_, err = g.Generate("test")
+	assert.Error(t, err, "entropy errors should be returned")
+}
+
+func TestGeneratorDeterministic(t *testing.T) {
+	replay := func() []string {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		g := NewGenerator(
+			WithClock(func() time.Time {
+				now = now.Add(time.Millisecond / 2)
+				return now
+			}),
+			WithEntropy(rand.NewChaCha8([32]byte{})),
+		)
+		var ids []string
+		for range 10 {
+			ids = append(ids, g.MustGenerate("user").String())
+		}
+		return ids
+	}
+
+	first := replay()
+	assert.Equal(t, first, replay(), "same clock and entropy should produce the same ids")
+	assert.True(t, slices.IsSorted(first))
+}
+
+func TestGeneratorFunc(t *testing.T) {
+	var gen IDGenerator = GeneratorFunc(Generate)
+	tid, err := gen.Generate("user")
+	require.NoError(t, err)
+	assert.Equal(t, "user", tid.Prefix())
+}
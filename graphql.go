@@ -0,0 +1,61 @@
+package typeid
+
+import (
+	"fmt"
+	"io"
+)
+
+// The methods in this file let TypeIDs be used as custom GraphQL scalars by
+// gqlgen and other servers that detect the MarshalGQL/UnmarshalGQL pair,
+// without depending on a GraphQL library. Map the scalar to the Go type in the
+// server's configuration, for example in gqlgen.yml:
+//
+//	models:
+//	  ID:
+//	    model: go.jetify.com/typeid/v2.TypeID
+
+// MarshalGQL writes the TypeID to w as a GraphQL string literal.
+func (tid TypeID) MarshalGQL(w io.Writer) {
+	// TypeIDs only contain [a-z0-9_], so the string never needs escaping
+	buf := make([]byte, 0, maxIDLen+2)
+	buf = append(buf, '"')
+	buf, _ = tid.AppendText(buf)
+	buf = append(buf, '"')
+	w.Write(buf)
+}
+
+// UnmarshalGQL parses a TypeID from a GraphQL input value, which must be a
+// string. Other types are rejected with a validation error.
+func (tid *TypeID) UnmarshalGQL(v any) error {
+	s, err := gqlString(v)
+	if err != nil {
+		return err
+	}
+	return tid.UnmarshalText([]byte(s))
+}
+
+// MarshalGQL writes the ID to w as a GraphQL string literal.
+func (id ID[P]) MarshalGQL(w io.Writer) {
+	id.tid.MarshalGQL(w)
+}
+
+// UnmarshalGQL parses an ID from a GraphQL input value like
+// TypeID.UnmarshalGQL, and additionally checks that its prefix matches P.
+func (id *ID[P]) UnmarshalGQL(v any) error {
+	s, err := gqlString(v)
+	if err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// gqlString returns the GraphQL input value v, which must be a string.
+func gqlString(v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", &validationError{
+			Message: fmt.Sprintf("GraphQL TypeID must be a string, got %T", v),
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,71 @@
+package typeid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestMarshalGQL(t *testing.T) {
+	var sb strings.Builder
+	typeid.MustParse("user_01h455vb4pex5vsknk084sn02q").MarshalGQL(&sb)
+	assert.Equal(t, `"user_01h455vb4pex5vsknk084sn02q"`, sb.String())
+
+	sb.Reset()
+	typeid.TypeID{}.MarshalGQL(&sb)
+	assert.Equal(t, `"00000000000000000000000000"`, sb.String())
+
+	sb.Reset()
+	id, err := typeid.FromTypeID[TestPrefix](typeid.MustParse("test_01h455vb4pex5vsknk084sn02q"))
+	require.NoError(t, err)
+	id.MarshalGQL(&sb)
+	assert.Equal(t, `"test_01h455vb4pex5vsknk084sn02q"`, sb.String())
+
+	// The output is a valid JSON string, as GraphQL responses are JSON
+	var decoded string
+	require.NoError(t, json.Unmarshal([]byte(sb.String()), &decoded))
+	assert.Equal(t, id.String(), decoded)
+}
+
+func TestUnmarshalGQL(t *testing.T) {
+	var tid typeid.TypeID
+	require.NoError(t, tid.UnmarshalGQL("user_01h455vb4pex5vsknk084sn02q"))
+	assert.Equal(t, typeid.MustParse("user_01h455vb4pex5vsknk084sn02q"), tid)
+
+	var id TestID
+	require.NoError(t, id.UnmarshalGQL("test_01h455vb4pex5vsknk084sn02q"))
+	assert.Equal(t, "test_01h455vb4pex5vsknk084sn02q", id.String())
+
+	err := id.UnmarshalGQL("user_01h455vb4pex5vsknk084sn02q")
+	var mismatch *typeid.PrefixMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+
+	testdata := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{"invalid string", "user_01h455vb4pex5vsknk084sn02", "typeid: suffix length must be 26, got 25"},
+		{"number", json.Number("123"), "typeid: GraphQL TypeID must be a string, got json.Number"},
+		{"int", 123, "typeid: GraphQL TypeID must be a string, got int"},
+		{"bytes", []byte("user_01h455vb4pex5vsknk084sn02q"), "typeid: GraphQL TypeID must be a string, got []uint8"},
+		{"null", nil, "typeid: GraphQL TypeID must be a string, got <nil>"},
+	}
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			var tid typeid.TypeID
+			err := tid.UnmarshalGQL(td.input)
+			assert.EqualError(t, err, td.expected)
+			assert.True(t, errors.Is(err, typeid.ErrValidation))
+
+			var id TestID
+			err = id.UnmarshalGQL(td.input)
+			assert.True(t, errors.Is(err, typeid.ErrValidation))
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package typeid
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"time"
+)
+
+// PrefixType is implemented by types that supply the prefix of an ID.
+// It is usually an empty struct declared once per entity:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+//
+//	type UserID = typeid.ID[UserPrefix]
+type PrefixType interface {
+	Prefix() string
+}
+
+// ID is a TypeID whose prefix is fixed at compile time by P.
+//
+// IDs with different prefix types are distinct Go types, so a user ID can't be
+// passed where an order ID is expected. Every constructor, as well as
+// UnmarshalText, Scan and Value, enforces that the prefix matches P and returns a
+// *PrefixMismatchError otherwise.
+//
+// The zero value of ID wraps the zero TypeID, which has no prefix. It is not a
+// valid ID[P] unless P's prefix is empty, so use `json:",omitzero"` or
+// sql.Null[ID[P]] for optional fields.
+type ID[P PrefixType] struct {
+	tid TypeID
+}
+
+var (
+	_ encoding.TextMarshaler     = (*ID[PrefixType])(nil)
+	_ encoding.TextUnmarshaler   = (*ID[PrefixType])(nil)
+	_ encoding.BinaryMarshaler   = (*ID[PrefixType])(nil)
+	_ encoding.BinaryUnmarshaler = (*ID[PrefixType])(nil)
+)
+
+// prefixOf returns the prefix supplied by P.
+func prefixOf[P PrefixType]() string {
+	var p P
+	return p.Prefix()
+}
+
+// GenerateID returns a new ID with P's prefix and a random suffix.
+func GenerateID[P PrefixType]() (ID[P], error) {
+	tid, err := Generate(prefixOf[P]())
+	if err != nil {
+		return ID[P]{}, err
+	}
+	return ID[P]{tid: tid}, nil
+}
+
+// MustGenerateID returns a new ID with P's prefix and a random suffix.
+// It panics if P's prefix is invalid. Use GenerateID() if you need error handling.
+func MustGenerateID[P PrefixType]() ID[P] {
+	id, err := GenerateID[P]()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ParseID parses an ID from a string of the form <prefix>_<suffix> and checks
+// that the prefix matches P.
+func ParseID[P PrefixType](s string) (ID[P], error) {
+	tid, err := ParseWithPrefix(s, prefixOf[P]())
+	if err != nil {
+		return ID[P]{}, err
+	}
+	return ID[P]{tid: tid}, nil
+}
+
+// FromTypeID converts a TypeID into an ID, checking that its prefix matches P.
+func FromTypeID[P PrefixType](tid TypeID) (ID[P], error) {
+	if err := checkPrefix(tid, prefixOf[P]()); err != nil {
+		return ID[P]{}, err
+	}
+	return ID[P]{tid: tid}, nil
+}
+
+// TypeID returns the underlying TypeID.
+func (id ID[P]) TypeID() TypeID {
+	return id.tid
+}
+
+// Prefix returns the type prefix of the ID
+func (id ID[P]) Prefix() string {
+	return id.tid.Prefix()
+}
+
+// TypePrefix returns P's prefix. Unlike Prefix, it doesn't depend on the ID's
+// value, so it also works on the zero ID, for example in code that inspects
+// struct fields with reflection.
+func (ID[P]) TypePrefix() string {
+	return prefixOf[P]()
+}
+
+// Suffix returns the suffix of the ID in it's canonical base32 representation.
+func (id ID[P]) Suffix() string {
+	return id.tid.Suffix()
+}
+
+// String returns the ID in it's canonical string representation of the form:
+// <prefix>_<suffix>
+func (id ID[P]) String() string {
+	return id.tid.String()
+}
+
+// Bytes decodes the ID's suffix as a UUID and returns it's bytes
+func (id ID[P]) Bytes() []byte {
+	return id.tid.Bytes()
+}
+
+// UUID decodes the ID's suffix as a UUID and returns it as a hex string
+func (id ID[P]) UUID() string {
+	return id.tid.UUID()
+}
+
+// IsV7 returns true if the ID's suffix encodes a UUIDv7.
+func (id ID[P]) IsV7() bool {
+	return id.tid.IsV7()
+}
+
+// Time returns the creation time embedded in the ID's UUIDv7 suffix.
+// See TypeID.Time for details.
+func (id ID[P]) Time() (time.Time, bool) {
+	return id.tid.Time()
+}
+
+// HasSuffix returns true if the ID has a non-zero suffix.
+func (id ID[P]) HasSuffix() bool {
+	return id.tid.HasSuffix()
+}
+
+// IsZero returns true if the ID is the zero value.
+func (id ID[P]) IsZero() bool {
+	return id.tid.IsZero()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It parses the ID using the same logic as ParseID()
+func (id *ID[P]) UnmarshalText(text []byte) error {
+	parsed, err := ParseID[P](string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id ID[P]) MarshalText() (text []byte, err error) {
+	return id.tid.AppendText(nil)
+}
+
+// AppendText appends the text representation of the ID to dst and returns
+// the extended buffer.
+func (id ID[P]) AppendText(dst []byte) ([]byte, error) {
+	return id.tid.AppendText(dst)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface using the
+// same layout as TypeID.MarshalBinary.
+func (id ID[P]) MarshalBinary() ([]byte, error) {
+	return id.tid.AppendBinary(nil)
+}
+
+// AppendBinary appends the binary representation of the ID to dst and returns
+// the extended buffer.
+func (id ID[P]) AppendBinary(dst []byte) ([]byte, error) {
+	return id.tid.AppendBinary(dst)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It checks that the decoded prefix matches P.
+func (id *ID[P]) UnmarshalBinary(data []byte) error {
+	var tid TypeID
+	if err := tid.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	parsed, err := FromTypeID[P](tid)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface using the binary encoding.
+func (id ID[P]) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface using the binary encoding.
+func (id *ID[P]) GobDecode(data []byte) error {
+	return id.UnmarshalBinary(data)
+}
+
+// Scan implements the sql.Scanner interface. It accepts the text form of the ID,
+// which must have P's prefix, as well as raw 16-byte UUIDs from BINARY(16) or
+// native uuid columns, which are given P's prefix.
+func (id *ID[P]) Scan(src any) error {
+	tid, err := scanWithPrefix(src, prefixOf[P]())
+	if err != nil {
+		return err
+	}
+	*id = ID[P]{tid: tid}
+	return nil
+}
+
+// Value implements the sql.Valuer interface. It returns an error if the
+// ID's prefix doesn't match P, which prevents writing zero values by mistake.
+func (id ID[P]) Value() (driver.Value, error) {
+	if err := checkPrefix(id.tid, prefixOf[P]()); err != nil {
+		return nil, err
+	}
+	return id.tid.Value()
+}
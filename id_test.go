@@ -0,0 +1,151 @@
+package typeid_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+// Prefix types shared by the typed ID tests and benchmarks
+type TestPrefix struct{}
+
+func (TestPrefix) Prefix() string { return "test" }
+
+type TestID = typeid.ID[TestPrefix]
+
+type OtherPrefix struct{}
+
+func (OtherPrefix) Prefix() string { return "other" }
+
+type EmptyPrefix struct{}
+
+func (EmptyPrefix) Prefix() string { return "" }
+
+type InvalidPrefix struct{}
+
+func (InvalidPrefix) Prefix() string { return "INVALID" }
+
+func TestGenerateID(t *testing.T) {
+	id, err := typeid.GenerateID[TestPrefix]()
+	require.NoError(t, err)
+	assert.Equal(t, "test", id.Prefix())
+	assert.True(t, id.HasSuffix())
+
+	untyped, err := typeid.GenerateID[EmptyPrefix]()
+	require.NoError(t, err)
+	assert.Equal(t, "", untyped.Prefix())
+
+	var zero TestID
+	assert.Equal(t, "", zero.Prefix())
+	assert.Equal(t, "test", zero.TypePrefix())
+
+	_, err = typeid.GenerateID[InvalidPrefix]()
+	assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+	assert.Panics(t, func() { typeid.MustGenerateID[InvalidPrefix]() })
+}
+
+func TestParseID(t *testing.T) {
+	testdata := []struct {
+		name     string
+		input    string
+		mismatch bool
+		invalid  bool
+	}{
+		{"matching prefix", "test_01h455vb4pex5vsknk084sn02q", false, false},
+		{"other prefix", "other_01h455vb4pex5vsknk084sn02q", true, false},
+		{"no prefix", "01h455vb4pex5vsknk084sn02q", true, false},
+		{"invalid typeid", "test_01h455vb4pex5vsknk084sn02", false, true},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			id, err := typeid.ParseID[TestPrefix](td.input)
+			if !td.mismatch && !td.invalid {
+				require.NoError(t, err)
+				assert.Equal(t, td.input, id.String())
+				assert.Equal(t, typeid.MustParse(td.input), id.TypeID())
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+			var mismatch *typeid.PrefixMismatchError
+			assert.Equal(t, td.mismatch, errors.As(err, &mismatch))
+			if td.mismatch {
+				assert.Equal(t, "test", mismatch.Expected)
+				assert.Equal(t, typeid.MustParse(td.input).Prefix(), mismatch.Actual)
+			}
+		})
+	}
+}
+
+func TestFromTypeID(t *testing.T) {
+	tid := typeid.MustGenerate("test")
+
+	id, err := typeid.FromTypeID[TestPrefix](tid)
+	require.NoError(t, err)
+	assert.Equal(t, tid, id.TypeID())
+	assert.Equal(t, tid.String(), id.String())
+	assert.Equal(t, tid.Suffix(), id.Suffix())
+	assert.Equal(t, tid.Bytes(), id.Bytes())
+	assert.Equal(t, tid.UUID(), id.UUID())
+
+	_, err = typeid.FromTypeID[OtherPrefix](tid)
+	var mismatch *typeid.PrefixMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, `typeid: expected prefix "other", got "test"`, err.Error())
+}
+
+func TestIDJSON(t *testing.T) {
+	type Order struct {
+		ID     typeid.ID[OtherPrefix] `json:"id"`
+		Parent TestID                 `json:"parent,omitzero"`
+	}
+
+	order := Order{ID: typeid.MustGenerateID[OtherPrefix]()}
+	encoded, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"`+order.ID.String()+`"}`, string(encoded))
+
+	var decoded Order
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, order, decoded)
+
+	// The wrong prefix is rejected during decoding
+	err = json.Unmarshal([]byte(`{"id":"test_01h455vb4pex5vsknk084sn02q"}`), &decoded)
+	var mismatch *typeid.PrefixMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+}
+
+func TestIDSQL(t *testing.T) {
+	id := typeid.MustGenerateID[TestPrefix]()
+
+	value, err := id.Value()
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), value)
+
+	var scanned TestID
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, id, scanned)
+
+	var other typeid.ID[OtherPrefix]
+	err = other.Scan(value)
+	assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+	assert.True(t, other.IsZero(), "failed scan should not modify the ID")
+
+	// The zero value doesn't carry the prefix and can't be written
+	_, err = TestID{}.Value()
+	var mismatch *typeid.PrefixMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+
+	var nullable sql.Null[TestID]
+	require.NoError(t, nullable.Scan(nil))
+	assert.False(t, nullable.Valid)
+	require.NoError(t, nullable.Scan(id.String()))
+	assert.Equal(t, id, nullable.V)
+}
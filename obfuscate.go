@@ -0,0 +1,138 @@
+package typeid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+
+	"go.jetify.com/typeid/v2/base32"
+)
+
+// Obfuscator maps internal TypeIDs to public TypeIDs that don't reveal when
+// they were created or how many ids exist, and back. The public id keeps the
+// prefix, so it can be used anywhere the internal id would be:
+//
+//	obf, err := typeid.NewObfuscator(typeid.ObfuscationKey{ID: 1, Secret: secret})
+//	public, err := obf.Obfuscate(userID)  // user_5b7k...
+//	internal, err := obf.Reveal(public)   // userID
+//
+// Only UUIDv7 TypeIDs can be obfuscated. Their 122 bits that aren't fixed by the
+// UUID version and variant are encrypted with AES, a keyed 128-bit block
+// permutation, using cycle walking to keep the result within 122 bits. The
+// other 6 bits of the public id hold the id of the key, so ids remain
+// reversible while keys are rotated. The mapping is deterministic and
+// collision-free for each key.
+//
+// Public ids are not UUIDs: their version and variant bits hold the key id, and
+// the rest is ciphertext. About 1 in 64 of them look like UUIDv7s by chance, so
+// don't pass them to TypeID.Time, TypeID.IsV7 or Obfuscate, which would report a
+// made-up creation time or obfuscate them a second time. Keep track of which ids
+// are public, for instance with distinct fields or types, and Reveal them first.
+//
+// Obfuscation isn't authentication: any public id with a known key id reveals
+// to some internal id. Sign public ids with a Signer to detect forged ones.
+//
+// An Obfuscator is safe for concurrent use; create one with NewObfuscator, the
+// zero value is not ready for use.
+type Obfuscator struct {
+	current uint8
+	blocks  [maxKeyID + 1]cipher.Block // Indexed by key id, nil for unknown keys
+}
+
+// ObfuscationKey is a secret key used by an Obfuscator.
+type ObfuscationKey struct {
+	// ID identifies the key in the public ids it produces, from 0 to 63.
+	ID uint8
+	// Secret is an AES key of 16, 24 or 32 bytes.
+	Secret []byte
+}
+
+// maxKeyID is the largest key id that fits in the 6 bits stored in public ids.
+const maxKeyID = 63
+
+// NewObfuscator returns an Obfuscator that obfuscates ids with the current key
+// and reveals ids obfuscated with the current or any of the previous keys. To
+// rotate keys, pass a new current key and move the old one to previous until
+// the public ids it produced are no longer in use.
+func NewObfuscator(current ObfuscationKey, previous ...ObfuscationKey) (*Obfuscator, error) {
+	o := &Obfuscator{current: current.ID}
+	for _, key := range append([]ObfuscationKey{current}, previous...) {
+		if key.ID > maxKeyID {
+			return nil, fmt.Errorf("typeid: obfuscation key id must be <= %d, got %d", maxKeyID, key.ID)
+		}
+		if o.blocks[key.ID] != nil {
+			return nil, fmt.Errorf("typeid: duplicate obfuscation key id %d", key.ID)
+		}
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("typeid: obfuscation key %d: %w", key.ID, err)
+		}
+		o.blocks[key.ID] = block
+	}
+	return o, nil
+}
+
+// Obfuscate returns the public id of tid, which must be a UUIDv7 TypeID.
+func (o *Obfuscator) Obfuscate(tid TypeID) (TypeID, error) {
+	uid := tid.uuidBytes()
+	if !isV7(uid) {
+		return zeroID, &validationError{
+			Message: "can only obfuscate UUIDv7 TypeIDs",
+		}
+	}
+
+	// Pack the 48-bit timestamp, 12-bit rand_a and 62-bit rand_b into the low
+	// 122 bits of the block
+	hi := binary.BigEndian.Uint64(uid[:8])
+	lo := binary.BigEndian.Uint64(uid[8:])
+	ts, randA, randB := hi>>16, hi&maxRandA, lo&maxRandB
+
+	var block [16]byte
+	binary.BigEndian.PutUint64(block[:8], ts<<10|randA>>2)
+	binary.BigEndian.PutUint64(block[8:], randA<<62|randB)
+	cycleWalk(o.blocks[o.current].Encrypt, &block)
+
+	block[0] |= o.current << 2
+	var suffixBuf [26]byte
+	base32.Encode(suffixBuf[:], block)
+	return newTypeID(tid.Prefix(), suffixBuf), nil
+}
+
+// Reveal returns the internal id of a public id returned by Obfuscate with any
+// of the Obfuscator's keys. It returns a validation error if the public id was
+// obfuscated with an unknown key.
+func (o *Obfuscator) Reveal(public TypeID) (TypeID, error) {
+	block := public.uuidBytes()
+	keyID := block[0] >> 2
+	if o.blocks[keyID] == nil {
+		return zeroID, &validationError{
+			Message: fmt.Sprintf("unknown obfuscation key id %d", keyID),
+		}
+	}
+
+	block[0] &= 0x03
+	cycleWalk(o.blocks[keyID].Decrypt, &block)
+
+	hi := binary.BigEndian.Uint64(block[:8])
+	lo := binary.BigEndian.Uint64(block[8:])
+	ts, randA, randB := hi>>10, (hi&0x3ff)<<2|lo>>62, lo&maxRandB
+
+	var uid [16]byte
+	binary.BigEndian.PutUint64(uid[:8], ts<<16|randA)
+	binary.BigEndian.PutUint64(uid[8:], randB)
+	setVersion7(&uid)
+	var suffixBuf [26]byte
+	base32.Encode(suffixBuf[:], uid)
+	return newTypeID(public.Prefix(), suffixBuf), nil
+}
+
+// cycleWalk applies the block permutation to block until the result has its
+// top 6 bits clear. Starting from such a block, this permutes the 2^122 blocks
+// with the top 6 bits clear, and takes 64 applications on average.
+func cycleWalk(permute func(dst, src []byte), block *[16]byte) {
+	permute(block[:], block[:])
+	for block[0]&0xfc != 0 {
+		permute(block[:], block[:])
+	}
+}
@@ -0,0 +1,152 @@
+package typeid_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+var (
+	testKey1 = typeid.ObfuscationKey{ID: 1, Secret: bytes.Repeat([]byte{1}, 16)}
+	testKey2 = typeid.ObfuscationKey{ID: 2, Secret: bytes.Repeat([]byte{2}, 32)}
+)
+
+func TestObfuscateRoundTrip(t *testing.T) {
+	obf, err := typeid.NewObfuscator(testKey1)
+	require.NoError(t, err)
+
+	// Ids generated in the same millisecond are sequential, public ids are not
+	gen := typeid.NewGenerator(
+		typeid.WithClock(func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }),
+		typeid.WithEntropy(rand.NewChaCha8([32]byte{})),
+	)
+	seen := map[typeid.TypeID]bool{}
+	var previous typeid.TypeID
+	for i := range 1000 {
+		tid := gen.MustGenerate("user")
+		if i%100 == 0 {
+			tid = typeid.MustGenerate("user")
+		}
+
+		public, err := obf.Obfuscate(tid)
+		require.NoError(t, err)
+		assert.Equal(t, "user", public.Prefix())
+		assert.NotEqual(t, tid.Suffix()[:10], public.Suffix()[:10], "the timestamp must not be visible")
+		assert.False(t, seen[public], "collision")
+		seen[public] = true
+		if i > 0 {
+			assert.NotEqual(t, previous.Suffix()[:20], public.Suffix()[:20])
+		}
+		previous = public
+
+		// The mapping is deterministic
+		again, err := obf.Obfuscate(tid)
+		require.NoError(t, err)
+		assert.Equal(t, public, again)
+
+		revealed, err := obf.Reveal(public)
+		require.NoError(t, err)
+		assert.Equal(t, tid, revealed)
+	}
+
+	// The smallest and largest UUIDv7s
+	lowest, err := typeid.MinForTime("", time.UnixMilli(0))
+	require.NoError(t, err)
+	highest, err := typeid.MaxForTime("", time.UnixMilli(1<<48-1))
+	require.NoError(t, err)
+	for _, tid := range []typeid.TypeID{lowest, highest} {
+		public, err := obf.Obfuscate(tid)
+		require.NoError(t, err)
+		revealed, err := obf.Reveal(public)
+		require.NoError(t, err)
+		assert.Equal(t, tid, revealed)
+	}
+}
+
+// TestObfuscatePublicLooksV7 pins the documented limit: some public ids look
+// like UUIDv7s, and nothing stops them from being obfuscated again.
+func TestObfuscatePublicLooksV7(t *testing.T) {
+	obf, err := typeid.NewObfuscator(testKey1)
+	require.NoError(t, err)
+
+	gen := typeid.NewGenerator(
+		typeid.WithClock(func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }),
+		typeid.WithEntropy(rand.NewChaCha8([32]byte{})),
+	)
+	lookalikes := 0
+	for range 1000 {
+		tid := gen.MustGenerate("user")
+		public, err := obf.Obfuscate(tid)
+		require.NoError(t, err)
+		if !public.IsV7() {
+			continue
+		}
+		lookalikes++
+
+		// The creation time is made up, and public ids can be obfuscated twice
+		created, ok := public.Time()
+		assert.True(t, ok)
+		expected, _ := tid.Time()
+		assert.NotEqual(t, expected, created)
+		twice, err := obf.Obfuscate(public)
+		require.NoError(t, err)
+		revealed, err := obf.Reveal(twice)
+		require.NoError(t, err)
+		assert.Equal(t, public, revealed)
+	}
+	// About 1 in 64
+	assert.Greater(t, lookalikes, 0)
+	assert.Less(t, lookalikes, 50)
+}
+
+func TestObfuscateKeyRotation(t *testing.T) {
+	tid := typeid.MustGenerate("order")
+
+	old, err := typeid.NewObfuscator(testKey1)
+	require.NoError(t, err)
+	oldPublic, err := old.Obfuscate(tid)
+	require.NoError(t, err)
+
+	rotated, err := typeid.NewObfuscator(testKey2, testKey1)
+	require.NoError(t, err)
+	newPublic, err := rotated.Obfuscate(tid)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldPublic, newPublic)
+
+	// Ids from both keys can be revealed
+	for _, public := range []typeid.TypeID{oldPublic, newPublic} {
+		revealed, err := rotated.Reveal(public)
+		require.NoError(t, err)
+		assert.Equal(t, tid, revealed)
+	}
+
+	// Ids from keys that were dropped can't
+	_, err = old.Reveal(newPublic)
+	assert.EqualError(t, err, "typeid: unknown obfuscation key id 2")
+	assert.True(t, errors.Is(err, typeid.ErrValidation))
+}
+
+func TestObfuscatorErrors(t *testing.T) {
+	_, err := typeid.NewObfuscator(typeid.ObfuscationKey{ID: 64, Secret: testKey1.Secret})
+	assert.EqualError(t, err, "typeid: obfuscation key id must be <= 63, got 64")
+
+	_, err = typeid.NewObfuscator(testKey1, typeid.ObfuscationKey{ID: 1, Secret: testKey2.Secret})
+	assert.EqualError(t, err, "typeid: duplicate obfuscation key id 1")
+
+	_, err = typeid.NewObfuscator(typeid.ObfuscationKey{ID: 3, Secret: []byte("short")})
+	assert.EqualError(t, err, "typeid: obfuscation key 3: crypto/aes: invalid key size 5")
+
+	obf, err := typeid.NewObfuscator(testKey1)
+	require.NoError(t, err)
+	for _, s := range []string{"00000000000000000000000000", "user_00041061050r3gg28a1c60t3gf"} {
+		_, err = obf.Obfuscate(typeid.MustParse(s))
+		assert.EqualError(t, err, "typeid: can only obfuscate UUIDv7 TypeIDs")
+		assert.True(t, errors.Is(err, typeid.ErrValidation))
+	}
+}
@@ -0,0 +1,141 @@
+package typeid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.jetify.com/typeid/v2/base32"
+)
+
+// ErrInvalidSignature is the cause of the validation error returned by
+// Signer.Verify when a well-formed token's tag wasn't issued with any of the
+// Signer's keys. Check for it with errors.Is to tell forged or corrupted tokens
+// apart from malformed ones.
+var ErrInvalidSignature = errors.New("tag does not match")
+
+// tagSeparator separates the TypeID from its tag in a signed token.
+const tagSeparator = '.'
+
+// Signer issues tokens that prove a TypeID was issued by the holder of a secret
+// key, for share links, invites and other capability URLs. A token is the
+// TypeID followed by a dot and a tag:
+//
+//	invite_01h455vb4pex5vsknk084sn02q.1esm8x5azt4d2yt4evx9mhxzw4
+//
+// The tag is the HMAC-SHA256 of the prefix length (1 byte), the prefix and the 16
+// UUID bytes, truncated to 128 bits and encoded with the same base32 alphabet
+// as TypeID suffixes, so it's always 26 characters. Tokens only contain
+// [a-z0-9_.] and can be used in URLs as is.
+//
+// A Signer is safe for concurrent use; create one with NewSigner, the zero
+// value is not ready for use.
+type Signer struct {
+	keys [][]byte // The current key first
+}
+
+// minSigningKeyLen is the minimum length of a signing key, in bytes.
+const minSigningKeyLen = 16
+
+// NewSigner returns a Signer that signs with the current key and accepts tokens
+// signed with the current or any of the previous keys. Keys must be at least 16
+// random bytes; 32 are recommended.
+func NewSigner(current []byte, previous ...[]byte) (*Signer, error) {
+	s := &Signer{}
+	for _, key := range append([][]byte{current}, previous...) {
+		if len(key) < minSigningKeyLen {
+			return nil, fmt.Errorf("typeid: signing key must be at least %d bytes, got %d", minSigningKeyLen, len(key))
+		}
+		s.keys = append(s.keys, append([]byte{}, key...))
+	}
+	return s, nil
+}
+
+// Sign returns the signed token of tid.
+func (s *Signer) Sign(tid TypeID) string {
+	tag := tagFor(s.keys[0], tid)
+	return tid.String() + string(tagSeparator) + string(tag[:])
+}
+
+// Verify parses a token returned by Sign and checks its tag in constant time.
+// It returns the TypeID if the tag was issued with one of the Signer's keys.
+// Otherwise it returns a validation error: for a missing or malformed tag, with
+// the offset of the offending character, and for a tag that doesn't match, with
+// ErrInvalidSignature as its cause.
+func (s *Signer) Verify(token string) (TypeID, error) {
+	sep := strings.LastIndexByte(token, tagSeparator)
+	if sep == -1 || sep == len(token)-1 {
+		return zeroID, &validationError{
+			Message: "missing signature tag",
+			pos:     len(token) + 1,
+		}
+	}
+
+	tid, err := Parse(token[:sep])
+	if err != nil {
+		return zeroID, err
+	}
+	tag := token[sep+1:]
+	if err := validateTag(tag, sep+1); err != nil {
+		return zeroID, err
+	}
+
+	for _, key := range s.keys {
+		expected := tagFor(key, tid)
+		if subtle.ConstantTimeCompare(expected[:], []byte(tag)) == 1 {
+			return tid, nil
+		}
+	}
+	return zeroID, &validationError{
+		Message: "invalid signature",
+		Cause:   ErrInvalidSignature,
+	}
+}
+
+// tagFor returns the encoded tag of tid with the given key.
+func tagFor(key []byte, tid TypeID) [26]byte {
+	prefix := tid.Prefix()
+	uid := tid.uuidBytes()
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte{byte(len(prefix))})
+	mac.Write([]byte(prefix))
+	mac.Write(uid[:])
+	sum := mac.Sum(nil)
+
+	var tag [26]byte
+	base32.Encode(tag[:], [16]byte(sum[:16]))
+	return tag
+}
+
+// validateTag checks that tag is a well-formed tag. start is the offset of the
+// tag within the token, used to report error positions.
+func validateTag(tag string, start int) error {
+	if len(tag) != 26 {
+		return &validationError{
+			Message: fmt.Sprintf("signature tag length must be 26, got %d", len(tag)),
+			pos:     start + min(len(tag), 26) + 1,
+		}
+	}
+	if tag[0] > '7' {
+		return &validationError{
+			Message: fmt.Sprintf("signature tag must start with 0-7, got %q", tag[0]),
+			pos:     start + 1,
+		}
+	}
+	if err := base32.ValidateString(tag); err != nil {
+		verr := &validationError{
+			Message: "invalid signature tag encoding",
+			Cause:   err,
+		}
+		var corrupt base32.CorruptInputError
+		if errors.As(err, &corrupt) {
+			verr.pos = start + int(corrupt) + 1
+		}
+		return verr
+	}
+	return nil
+}
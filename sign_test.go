@@ -0,0 +1,120 @@
+package typeid_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+var (
+	testSigningKey1 = bytes.Repeat([]byte{1}, 32)
+	testSigningKey2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestSignVerify(t *testing.T) {
+	signer, err := typeid.NewSigner(testSigningKey1)
+	require.NoError(t, err)
+
+	for _, tid := range []typeid.TypeID{
+		typeid.MustGenerate("invite"),
+		typeid.MustGenerate(""),
+		typeid.MustParse("a_b_00041061050r3gg28a1c60t3gf"),
+		{},
+	} {
+		token := signer.Sign(tid)
+		assert.True(t, strings.HasPrefix(token, tid.String()+"."), token)
+		assert.Len(t, token, len(tid.String())+1+26)
+
+		verified, err := signer.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, tid, verified)
+	}
+}
+
+func TestSignKeyRotation(t *testing.T) {
+	tid := typeid.MustGenerate("share")
+	old, err := typeid.NewSigner(testSigningKey1)
+	require.NoError(t, err)
+	rotated, err := typeid.NewSigner(testSigningKey2, testSigningKey1)
+	require.NoError(t, err)
+
+	oldToken, newToken := old.Sign(tid), rotated.Sign(tid)
+	assert.NotEqual(t, oldToken, newToken)
+
+	for _, token := range []string{oldToken, newToken} {
+		verified, err := rotated.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, tid, verified)
+	}
+
+	_, err = old.Verify(newToken)
+	assert.True(t, errors.Is(err, typeid.ErrInvalidSignature))
+}
+
+func TestVerifyErrors(t *testing.T) {
+	signer, err := typeid.NewSigner(testSigningKey1)
+	require.NoError(t, err)
+	token := signer.Sign(typeid.MustParse("invite_01h455vb4pex5vsknk084sn02q"))
+	tag := token[strings.IndexByte(token, '.')+1:]
+
+	// A tag for the same UUID with a different prefix
+	otherPrefix := signer.Sign(typeid.MustParse("admin_01h455vb4pex5vsknk084sn02q"))
+	otherTag := otherPrefix[strings.IndexByte(otherPrefix, '.')+1:]
+
+	// Flip the last character of the tag
+	last := "0"
+	if strings.HasSuffix(tag, "0") {
+		last = "1"
+	}
+
+	testdata := []struct {
+		name     string
+		token    string
+		expected string
+		offset   int // -1 if unknown
+		forgery  bool
+	}{
+		{"missing tag", "invite_01h455vb4pex5vsknk084sn02q", "typeid: missing signature tag", 33, false},
+		{"empty tag", "invite_01h455vb4pex5vsknk084sn02q.", "typeid: missing signature tag", 34, false},
+		{"invalid typeid", "Invite_01h455vb4pex5vsknk084sn02q." + tag, `typeid: prefix must contain only [a-z_], found 'I' in "Invite"`, 0, false},
+		{"short tag", "invite_01h455vb4pex5vsknk084sn02q." + tag[:20], "typeid: signature tag length must be 26, got 20", 54, false},
+		{"tag overflow", "invite_01h455vb4pex5vsknk084sn02q.8" + tag[1:], "typeid: signature tag must start with 0-7, got '8'", 34, false},
+		{"invalid tag", "invite_01h455vb4pex5vsknk084sn02q." + tag[:5] + "u" + tag[6:], "typeid: invalid signature tag encoding: illegal base32 data at offset 5", 39, false},
+		{"modified tag", token[:len(token)-1] + last, "typeid: invalid signature: tag does not match", -1, true},
+		{"modified id", strings.Replace(token, "02q.", "02r.", 1), "typeid: invalid signature: tag does not match", -1, true},
+		{"swapped prefix", "invite_01h455vb4pex5vsknk084sn02q." + otherTag, "typeid: invalid signature: tag does not match", -1, true},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			tid, err := signer.Verify(td.token)
+			assert.EqualError(t, err, td.expected)
+			assert.True(t, tid.IsZero())
+			assert.True(t, errors.Is(err, typeid.ErrValidation))
+			assert.Equal(t, td.forgery, errors.Is(err, typeid.ErrInvalidSignature))
+
+			var located interface{ Offset() (int, bool) }
+			require.True(t, errors.As(err, &located))
+			offset, ok := located.Offset()
+			if td.offset == -1 {
+				assert.False(t, ok)
+			} else {
+				assert.True(t, ok)
+				assert.Equal(t, td.offset, offset)
+			}
+		})
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	_, err := typeid.NewSigner([]byte("short"))
+	assert.EqualError(t, err, "typeid: signing key must be at least 16 bytes, got 5")
+
+	_, err = typeid.NewSigner(testSigningKey1, nil)
+	assert.EqualError(t, err, "typeid: signing key must be at least 16 bytes, got 0")
+}
@@ -0,0 +1,179 @@
+package typeid
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	_ slog.LogValuer = TypeID{}
+	_ slog.Handler   = (*redactingHandler)(nil)
+)
+
+// LogValue implements the slog.LogValuer interface. A TypeID is logged as a
+// group with its prefix, its suffix and, for UUIDv7s, its creation time:
+//
+//	slog.Info("created", "user", tid)
+//	// msg=created user.prefix=user user.suffix=01h455vb4pex5vsknk084sn02q user.time=2023-06-30T...
+func (tid TypeID) LogValue() slog.Value {
+	return logValue(tid, false)
+}
+
+// LogValue implements the slog.LogValuer interface, see TypeID.LogValue.
+func (id ID[P]) LogValue() slog.Value {
+	return id.tid.LogValue()
+}
+
+// redactedLen is the length of the random part of a suffix that is hidden by
+// redaction. The first 10 characters of a UUIDv7 suffix encode the timestamp.
+const redactedLen = 16
+
+// logValue returns the group logged for tid, with the random part of the suffix
+// masked if redact is set.
+func logValue(tid TypeID, redact bool) slog.Value {
+	suffix := tid.Suffix()
+	if redact {
+		suffix = redactSuffix(suffix)
+	}
+	attrs := []slog.Attr{
+		slog.String("prefix", tid.Prefix()),
+		slog.String("suffix", suffix),
+	}
+	if t, ok := tid.Time(); ok {
+		attrs = append(attrs, slog.Time("time", t))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redactSuffix returns suffix with its random part masked.
+func redactSuffix(suffix string) string {
+	return suffix[:len(suffix)-redactedLen] + strings.Repeat("*", redactedLen)
+}
+
+// NewRedactingHandler returns a slog.Handler that passes records on to h after
+// redacting the TypeIDs with one of the given prefixes, such as session ids.
+// Their random part is replaced with asterisks while the prefix and creation
+// time are kept, so call sites can log them like any other id:
+//
+//	logger := slog.New(typeid.NewRedactingHandler(handler, "session"))
+//	logger.Info("login", "session", sessionID)
+//	// msg=login session.prefix=session session.suffix=01h455vb4p**************** session.time=...
+//
+// TypeIDs and IDs, and pointers to them, are redacted when they are attribute
+// values, including inside groups and in attributes added with Logger.With.
+// Slices and arrays of TypeIDs or IDs are logged as lists of strings, with the
+// sensitive ones redacted. Ids nested in other values, such as structs, maps or
+// sql.Null[TypeID], are not redacted: log them as attributes of their own.
+func NewRedactingHandler(h slog.Handler, prefixes ...string) slog.Handler {
+	return &redactingHandler{h: h, prefixes: slices.Clone(prefixes)}
+}
+
+// redactingHandler is the handler returned by NewRedactingHandler.
+type redactingHandler struct {
+	h        slog.Handler
+	prefixes []string
+}
+
+func (r *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return r.h.Enabled(ctx, level)
+}
+
+func (r *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(r.redact(a))
+		return true
+	})
+	return r.h.Handle(ctx, redacted)
+}
+
+func (r *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = r.redact(a)
+	}
+	return &redactingHandler{h: r.h.WithAttrs(redacted), prefixes: r.prefixes}
+}
+
+func (r *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{h: r.h.WithGroup(name), prefixes: r.prefixes}
+}
+
+// redact returns a with any sensitive TypeID in its value redacted.
+func (r *redactingHandler) redact(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = r.redact(ga)
+		}
+		a.Value = slog.GroupValue(redacted...)
+	case slog.KindLogValuer:
+		if tid, ok := asTypeID(a.Value.Any()); ok && r.sensitive(tid) {
+			a.Value = logValue(tid, true)
+		}
+	case slog.KindAny:
+		if ids, ok := r.redactSlice(a.Value.Any()); ok {
+			a.Value = slog.AnyValue(ids)
+		}
+	}
+	return a
+}
+
+// redactSlice returns the ids in v, a slice or array of TypeIDs or IDs (not
+// pointers to them), as
+// strings with the sensitive ones redacted. It returns false if v holds no
+// sensitive id.
+func (r *redactingHandler) redactSlice(v any) ([]string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	elem := rv.Type().Elem()
+	if elem.Kind() == reflect.Pointer ||
+		(elem != reflect.TypeFor[TypeID]() && !elem.Implements(reflect.TypeFor[interface{ TypeID() TypeID }]())) {
+		return nil, false
+	}
+
+	ids := make([]string, rv.Len())
+	found := false
+	for i := range ids {
+		tid, _ := asTypeID(rv.Index(i).Interface())
+		ids[i] = tid.String()
+		if r.sensitive(tid) {
+			ids[i] = tid.Prefix() + "_" + redactSuffix(tid.Suffix())
+			found = true
+		}
+	}
+	return ids, found
+}
+
+// sensitive reports whether tid has one of the redacted prefixes.
+func (r *redactingHandler) sensitive(tid TypeID) bool {
+	return slices.Contains(r.prefixes, tid.Prefix())
+}
+
+// asTypeID returns the TypeID held by v, which may be a TypeID, an ID or a
+// pointer to either. It returns false for other values and nil pointers.
+func asTypeID(v any) (TypeID, bool) {
+	switch v := v.(type) {
+	case TypeID:
+		return v, true
+	case *TypeID:
+		if v == nil {
+			return zeroID, false
+		}
+		return *v, true
+	case interface{ TypeID() TypeID }:
+		// Pointers to IDs have the method too, but can't be called when nil
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return zeroID, false
+		}
+		return v.TypeID(), true
+	}
+	return zeroID, false
+}
@@ -0,0 +1,110 @@
+package typeid_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+// newTestLogger returns a logger writing JSON records without a timestamp to buf.
+func newTestLogger(buf *bytes.Buffer, sensitive ...string) *slog.Logger {
+	var h slog.Handler = slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	if sensitive != nil {
+		h = typeid.NewRedactingHandler(h, sensitive...)
+	}
+	return slog.New(h)
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	user := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	logger.Info("created", "user", user)
+	assert.JSONEq(t, `{"level":"INFO","msg":"created","user":{"prefix":"user","suffix":"01h455vb4pex5vsknk084sn02q","time":"2023-06-30T03:34:18.518Z"}}`, buf.String())
+
+	// Ids that aren't UUIDv7s have no creation time
+	buf.Reset()
+	logger.Info("zero", "id", typeid.TypeID{})
+	assert.JSONEq(t, `{"level":"INFO","msg":"zero","id":{"prefix":"","suffix":"00000000000000000000000000"}}`, buf.String())
+
+	buf.Reset()
+	id, err := typeid.FromTypeID[TestPrefix](typeid.MustParse("test_01h455vb4pex5vsknk084sn02q"))
+	require.NoError(t, err)
+	logger.Info("typed", "id", id)
+	assert.JSONEq(t, `{"level":"INFO","msg":"typed","id":{"prefix":"test","suffix":"01h455vb4pex5vsknk084sn02q","time":"2023-06-30T03:34:18.518Z"}}`, buf.String())
+}
+
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf, "session", "test")
+
+	session := typeid.MustParse("session_01h455vb4pex5vsknk084sn02q")
+	user := typeid.MustParse("user_01h455vb4pex5vsknk084sn02q")
+	test, err := typeid.FromTypeID[TestPrefix](typeid.MustParse("test_01h455vb4pex5vsknk084sn02q"))
+	require.NoError(t, err)
+
+	logger.With("session", session).WithGroup("request").Info("login",
+		"user", user,
+		"test", test,
+		slog.Group("auth", "session", session, "method", "password"),
+		"raw", session.String(),
+	)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	redacted := map[string]any{"prefix": "session", "suffix": "01h455vb4p****************", "time": "2023-06-30T03:34:18.518Z"}
+	assert.Equal(t, redacted, record["session"])
+
+	request := record["request"].(map[string]any)
+	assert.Equal(t, "01h455vb4pex5vsknk084sn02q", request["user"].(map[string]any)["suffix"])
+	assert.Equal(t, "01h455vb4p****************", request["test"].(map[string]any)["suffix"])
+	assert.Equal(t, map[string]any{"session": redacted, "method": "password"}, request["auth"])
+	// Only TypeID values are redacted, not strings that look like ids
+	assert.Equal(t, session.String(), request["raw"])
+
+	// Pointers are logged through LogValue too, so they must be redacted
+	buf.Reset()
+	logger.Info("pointers", "session", &session, "test", &test, "user", &user)
+	record = map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, redacted, record["session"])
+	assert.Equal(t, "01h455vb4p****************", record["test"].(map[string]any)["suffix"])
+	assert.Equal(t, "01h455vb4pex5vsknk084sn02q", record["user"].(map[string]any)["suffix"])
+
+	// Nil pointers are passed on as is
+	buf.Reset()
+	assert.NotPanics(t, func() {
+		logger.Info("nil", "session", (*typeid.TypeID)(nil), "test", (*typeid.ID[TestPrefix])(nil))
+	})
+	assert.Contains(t, buf.String(), `"msg":"nil"`)
+
+	// Slices of ids are redacted element by element
+	buf.Reset()
+	logger.Info("slices", "many", []typeid.TypeID{session, user}, "typed", [1]typeid.ID[TestPrefix]{test}, "users", []typeid.TypeID{user})
+	record = map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, []any{"session_01h455vb4p****************", user.String()}, record["many"])
+	assert.Equal(t, []any{"test_01h455vb4p****************"}, record["typed"])
+	assert.Equal(t, []any{user.String()}, record["users"])
+
+	// Ids nested in other values are not redacted, as documented
+	buf.Reset()
+	logger.Info("nested", "req", struct{ Session typeid.TypeID }{session})
+	assert.Contains(t, buf.String(), `"req":{"Session":"session_01h455vb4pex5vsknk084sn02q"}`)
+
+	assert.True(t, logger.Enabled(t.Context(), slog.LevelInfo))
+	assert.False(t, logger.Enabled(t.Context(), slog.LevelDebug))
+}
@@ -1,16 +1,36 @@
 package typeid
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 )
 
 // For nullable TypeID columns, use sql.Null[TypeID].
 
+var (
+	_ sql.Scanner   = (*TypeID)(nil)
+	_ driver.Valuer = (*TypeID)(nil)
+)
+
 // Scan implements the sql.Scanner interface so the TypeIDs can be read from
-// databases transparently. Currently database types that map to string are
+// databases transparently. Database types that map to string or []byte are
 // supported.
+//
+// Raw 16-byte UUIDs, as returned for BINARY(16) and native uuid columns, don't
+// carry a prefix and are rejected. Scan them with an ID, which supplies its own
+// prefix, or with ScanWithPrefix.
 func (tid *TypeID) Scan(src any) error {
+	if b, ok := src.([]byte); ok && len(b) == 16 {
+		return &validationError{
+			Message: "cannot scan raw UUID bytes into TypeID without a prefix",
+		}
+	}
+	return tid.scanText(src)
+}
+
+// scanText scans the text form of a TypeID.
+func (tid *TypeID) scanText(src any) error {
 	switch obj := src.(type) {
 	case nil:
 		return &validationError{
@@ -23,6 +43,13 @@ func (tid *TypeID) Scan(src any) error {
 			}
 		}
 		return tid.UnmarshalText([]byte(obj))
+	case []byte:
+		if len(obj) == 0 {
+			return &validationError{
+				Message: "cannot scan empty string into TypeID",
+			}
+		}
+		return tid.UnmarshalText(obj)
 	default:
 		return &validationError{
 			Message: fmt.Sprintf("unsupported scan type %T", obj),
@@ -30,6 +57,49 @@ func (tid *TypeID) Scan(src any) error {
 	}
 }
 
+// ScanWithPrefix returns a sql.Scanner that scans into dst and requires the TypeID
+// to have the given prefix. In addition to the text form accepted by
+// TypeID.Scan, it accepts raw 16-byte UUIDs and attaches the prefix to them.
+//
+//	var tid typeid.TypeID
+//	err := row.Scan(typeid.ScanWithPrefix(&tid, "user"))
+func ScanWithPrefix(dst *TypeID, prefix string) sql.Scanner {
+	return &prefixScanner{dst: dst, prefix: prefix}
+}
+
+// prefixScanner is the sql.Scanner returned by ScanWithPrefix.
+type prefixScanner struct {
+	dst    *TypeID
+	prefix string
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *prefixScanner) Scan(src any) error {
+	tid, err := scanWithPrefix(src, s.prefix)
+	if err != nil {
+		return err
+	}
+	*s.dst = tid
+	return nil
+}
+
+// scanWithPrefix scans src as either a raw 16-byte UUID, which is given the
+// prefix, or as the text form of a TypeID, which must have the prefix.
+func scanWithPrefix(src any, prefix string) (TypeID, error) {
+	if b, ok := src.([]byte); ok && len(b) == 16 {
+		return FromBytes(prefix, b)
+	}
+
+	var tid TypeID
+	if err := tid.scanText(src); err != nil {
+		return zeroID, err
+	}
+	if err := checkPrefix(tid, prefix); err != nil {
+		return zeroID, err
+	}
+	return tid, nil
+}
+
 // Value implements the sql.Valuer interface so that TypeIDs can be written
 // to databases transparently. Currently, TypeIDs map to strings.
 func (tid TypeID) Value() (driver.Value, error) {
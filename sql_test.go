@@ -86,7 +86,6 @@ func TestScanUnsupportedType(t *testing.T) {
 		{"int", 123},
 		{"float64", 123.45},
 		{"bool", true},
-		{"[]byte", []byte("test")},
 		{"time.Time", time.Now()},
 		{"struct", struct{ field string }{field: "test"}},
 		{"map", map[string]string{"key": "value"}},
@@ -223,7 +222,6 @@ func TestSQLNullScanUnsupportedType(t *testing.T) {
 		{"int", 123},
 		{"float64", 123.45},
 		{"bool", true},
-		{"[]byte", []byte("test")},
 		{"time.Time", time.Now()},
 		{"struct", struct{ field string }{field: "test"}},
 		{"map", map[string]string{"key": "value"}},
@@ -242,3 +240,73 @@ func TestSQLNullScanUnsupportedType(t *testing.T) {
 		})
 	}
 }
+
+func TestScanBytes(t *testing.T) {
+	var testdata []ValidExample
+	err := yaml.Unmarshal(validSQLYML, &testdata)
+	require.NoError(t, err)
+
+	for _, td := range testdata {
+		t.Run(td.Name, func(t *testing.T) {
+			expected := typeid.MustParse(td.Tid)
+
+			// Text columns returned as []byte
+			var scanned typeid.TypeID
+			require.NoError(t, scanned.Scan([]byte(td.Tid)))
+			assert.Equal(t, expected, scanned)
+
+			// Raw UUID bytes need a prefix
+			err := scanned.Scan(expected.Bytes())
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+
+			var withPrefix typeid.TypeID
+			require.NoError(t, typeid.ScanWithPrefix(&withPrefix, td.Prefix).Scan(expected.Bytes()))
+			assert.Equal(t, expected, withPrefix)
+			require.NoError(t, typeid.ScanWithPrefix(&withPrefix, td.Prefix).Scan([]byte(td.Tid)))
+			assert.Equal(t, expected, withPrefix)
+		})
+	}
+}
+
+func TestScanBytesSpecialCases(t *testing.T) {
+	testdata := []struct {
+		name  string
+		input []byte
+	}{
+		{"empty", []byte{}},
+		{"invalid text", []byte("test")},
+		{"15 bytes", make([]byte, 15)},
+		{"17 bytes", make([]byte, 17)},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			var scanned typeid.TypeID
+			err := scanned.Scan(td.input)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+
+			err = typeid.ScanWithPrefix(&scanned, "user").Scan(td.input)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+		})
+	}
+}
+
+func TestScanWithPrefix(t *testing.T) {
+	var tid typeid.TypeID
+	scanner := typeid.ScanWithPrefix(&tid, "user")
+
+	require.NoError(t, scanner.Scan("user_01h455vb4pex5vsknk084sn02q"))
+	assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q", tid.String())
+
+	err := scanner.Scan("order_01h455vb4pex5vsknk084sn02q")
+	var mismatch *typeid.PrefixMismatchError
+	require.True(t, errors.As(err, &mismatch))
+	assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q", tid.String(), "failed scan should not modify the TypeID")
+
+	err = scanner.Scan(nil)
+	assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+
+	var typed TestID
+	require.NoError(t, typed.Scan(tid.Bytes()))
+	assert.Equal(t, "test_01h455vb4pex5vsknk084sn02q", typed.String())
+}
@@ -0,0 +1,101 @@
+package typeid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var (
+	_ sql.Scanner   = (*UUIDValue)(nil)
+	_ driver.Valuer = (*UUIDValue)(nil)
+)
+
+// UUIDValue stores a TypeID in a database column of type uuid or BINARY(16).
+//
+// Only the UUID is persisted. The prefix is fixed per column: it's checked when
+// writing and re-attached when reading. Create one with AsUUID or AsNullUUID and
+// pass it to Exec or Scan in place of the TypeID:
+//
+//	var tid typeid.TypeID
+//	err := row.Scan(typeid.AsUUID(&tid, "user"))
+//	_, err = db.Exec("INSERT INTO users (id) VALUES ($1)", typeid.AsUUID(&tid, "user"))
+type UUIDValue struct {
+	tid    *TypeID
+	valid  *bool // Set for nullable columns
+	prefix string
+	binary bool
+}
+
+// AsUUID returns a UUIDValue that reads and writes tid as a UUID with the given
+// prefix. By default the UUID is written in its canonical hyphenated form; call
+// Binary to write the 16 raw bytes instead.
+func AsUUID(tid *TypeID, prefix string) *UUIDValue {
+	return &UUIDValue{tid: tid, prefix: prefix}
+}
+
+// AsNullUUID is like AsUUID for nullable columns. NULL is read as an invalid
+// sql.Null and an invalid sql.Null is written as NULL.
+func AsNullUUID(tid *sql.Null[TypeID], prefix string) *UUIDValue {
+	return &UUIDValue{tid: &tid.V, valid: &tid.Valid, prefix: prefix}
+}
+
+// Binary makes Value write the UUID as 16 raw bytes, for BINARY(16) columns
+// and drivers that expect bytes for native uuid columns. It returns u.
+func (u *UUIDValue) Binary() *UUIDValue {
+	u.binary = true
+	return u
+}
+
+// Scan implements the sql.Scanner interface. It accepts a UUID in any of its
+// string forms, as a string or []byte, or as 16 raw bytes.
+func (u *UUIDValue) Scan(src any) error {
+	var tid TypeID
+	var err error
+	switch obj := src.(type) {
+	case nil:
+		if u.valid == nil {
+			return &validationError{
+				Message: "cannot scan NULL into TypeID",
+			}
+		}
+		*u.tid, *u.valid = zeroID, false
+		return nil
+	case string:
+		tid, err = FromUUID(u.prefix, obj)
+	case []byte:
+		if len(obj) == 16 {
+			tid, err = FromBytes(u.prefix, obj)
+		} else {
+			tid, err = FromUUID(u.prefix, string(obj))
+		}
+	default:
+		return &validationError{
+			Message: fmt.Sprintf("unsupported scan type %T", obj),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	*u.tid = tid
+	if u.valid != nil {
+		*u.valid = true
+	}
+	return nil
+}
+
+// Value implements the sql.Valuer interface. It returns an error if the TypeID
+// doesn't have the column's prefix, so ids of the wrong type aren't stored.
+func (u *UUIDValue) Value() (driver.Value, error) {
+	if u.valid != nil && !*u.valid {
+		return nil, nil
+	}
+	if err := checkPrefix(*u.tid, u.prefix); err != nil {
+		return nil, err
+	}
+	if u.binary {
+		return u.tid.Bytes(), nil
+	}
+	return u.tid.UUID(), nil
+}
@@ -0,0 +1,97 @@
+package typeid_test
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+)
+
+func TestUUIDValueValid(t *testing.T) {
+	var testdata []ValidExample
+	err := yaml.Unmarshal(validSQLYML, &testdata)
+	require.NoError(t, err)
+
+	for _, td := range testdata {
+		t.Run(td.Name, func(t *testing.T) {
+			expected := typeid.MustParse(td.Tid)
+			uid := uuid.Must(uuid.FromString(td.UUID))
+
+			// Value writes the UUID only
+			value, err := typeid.AsUUID(&expected, td.Prefix).Value()
+			require.NoError(t, err)
+			assert.Equal(t, td.UUID, value)
+
+			value, err = typeid.AsUUID(&expected, td.Prefix).Binary().Value()
+			require.NoError(t, err)
+			assert.Equal(t, uid.Bytes(), value)
+
+			// Scan re-attaches the prefix to every UUID form
+			for _, src := range []any{td.UUID, []byte(td.UUID), uid.Bytes(), strings.ToUpper(td.UUID)} {
+				var scanned typeid.TypeID
+				require.NoError(t, typeid.AsUUID(&scanned, td.Prefix).Scan(src))
+				assert.Equal(t, expected, scanned)
+			}
+		})
+	}
+}
+
+func TestUUIDValueErrors(t *testing.T) {
+	var tid typeid.TypeID
+	testdata := []struct {
+		name string
+		src  any
+	}{
+		{"nil", nil},
+		{"invalid uuid", "not-a-uuid"},
+		{"typeid", "user_01h455vb4pex5vsknk084sn02q"},
+		{"short bytes", make([]byte, 15)},
+		{"int", 123},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			err := typeid.AsUUID(&tid, "user").Scan(td.src)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+		})
+	}
+
+	t.Run("wrong prefix", func(t *testing.T) {
+		order := typeid.MustGenerate("order")
+		_, err := typeid.AsUUID(&order, "user").Value()
+		var mismatch *typeid.PrefixMismatchError
+		assert.True(t, errors.As(err, &mismatch))
+
+		// The zero TypeID doesn't have the prefix either
+		_, err = typeid.AsUUID(&typeid.TypeID{}, "user").Value()
+		assert.True(t, errors.As(err, &mismatch))
+	})
+}
+
+func TestNullUUIDValue(t *testing.T) {
+	id := typeid.MustGenerate("user")
+
+	var nullable sql.Null[typeid.TypeID]
+	require.NoError(t, typeid.AsNullUUID(&nullable, "user").Scan(id.UUID()))
+	assert.True(t, nullable.Valid)
+	assert.Equal(t, id, nullable.V)
+
+	value, err := typeid.AsNullUUID(&nullable, "user").Value()
+	require.NoError(t, err)
+	assert.Equal(t, id.UUID(), value)
+
+	require.NoError(t, typeid.AsNullUUID(&nullable, "user").Scan(nil))
+	assert.False(t, nullable.Valid)
+	assert.True(t, nullable.V.IsZero())
+
+	value, err = typeid.AsNullUUID(&nullable, "user").Binary().Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
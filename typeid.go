@@ -1,6 +1,8 @@
 package typeid
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid/v5"
 	"go.jetify.com/typeid/v2/base32"
 )
@@ -55,6 +57,12 @@ func (tid TypeID) String() string {
 
 // Bytes decodes the TypeID's suffix as a UUID and returns it's bytes
 func (tid TypeID) Bytes() []byte {
+	dst := tid.uuidBytes()
+	return dst[:]
+}
+
+// uuidBytes decodes the TypeID's suffix into a UUID array without allocating.
+func (tid TypeID) uuidBytes() [16]byte {
 	suffix := tid.Suffix()
 	var dst [16]byte
 	_, err := base32.Decode(dst[:], []byte(suffix))
@@ -66,7 +74,7 @@ func (tid TypeID) Bytes() []byte {
 	if err != nil {
 		panic(err)
 	}
-	return dst[:]
+	return dst
 }
 
 // UUID decodes the TypeID's suffix as a UUID and returns it as a hex string
@@ -74,6 +82,38 @@ func (tid TypeID) UUID() string {
 	return uuid.FromBytesOrNil(tid.Bytes()).String()
 }
 
+// IsV7 returns true if the TypeID's suffix encodes a UUIDv7, which is the
+// case for every TypeID created by Generate or a Generator.
+func (tid TypeID) IsV7() bool {
+	uid := tid.uuidBytes()
+	return isV7(uid)
+}
+
+// isV7 checks the version and variant bits of a UUID.
+func isV7(uid [16]byte) bool {
+	return uid[6]>>4 == 7 && uid[8]>>6 == 0b10
+}
+
+// Time returns the creation time embedded in the TypeID's UUIDv7 suffix, in UTC
+// and with millisecond precision.
+//
+// The boolean is false if the suffix is not a UUIDv7 (for example an id created
+// with FromUUID from a UUIDv4), in which case there is no meaningful timestamp
+// and the zero time is returned.
+func (tid TypeID) Time() (time.Time, bool) {
+	uid := tid.uuidBytes()
+	if !isV7(uid) {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(int64(timestamp(uid))).UTC(), true
+}
+
+// timestamp returns the 48-bit big-endian millisecond timestamp of a UUIDv7.
+func timestamp(uid [16]byte) uint64 {
+	return uint64(uid[0])<<40 | uint64(uid[1])<<32 | uint64(uid[2])<<24 |
+		uint64(uid[3])<<16 | uint64(uid[4])<<8 | uint64(uid[5])
+}
+
 // HasSuffix returns true if the TypeID has a non-zero suffix.
 //
 // This method returns false only when the suffix is the zero suffix:
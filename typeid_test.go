@@ -2,7 +2,9 @@ package typeid_test
 
 import (
 	_ "embed"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/gofrs/uuid/v5"
@@ -350,3 +352,96 @@ func TestIsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWithPrefix(t *testing.T) {
+	testdata := []struct {
+		name     string
+		input    string
+		prefixes []string
+		mismatch bool
+		invalid  bool
+	}{
+		{"matching prefix", "user_01h455vb4pex5vsknk084sn02q", []string{"user"}, false, false},
+		{"other prefix", "order_01h455vb4pex5vsknk084sn02q", []string{"user"}, true, false},
+		{"required empty prefix", "01h455vb4pex5vsknk084sn02q", []string{""}, false, false},
+		{"missing prefix", "01h455vb4pex5vsknk084sn02q", []string{"user"}, true, false},
+		{"invalid typeid", "user_01h455vb4pex5vsknk084sn0", []string{"user"}, false, true},
+		{"one of many", "order_01h455vb4pex5vsknk084sn02q", []string{"user", "order"}, false, false},
+		{"none of many", "item_01h455vb4pex5vsknk084sn02q", []string{"user", "order"}, true, false},
+		{"no allowed prefixes", "item_01h455vb4pex5vsknk084sn02q", nil, true, false},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			var tid typeid.TypeID
+			var err error
+			if len(td.prefixes) == 1 {
+				tid, err = typeid.ParseWithPrefix(td.input, td.prefixes[0])
+			} else {
+				tid, err = typeid.ParseWithPrefixes(td.input, td.prefixes...)
+			}
+
+			if !td.mismatch && !td.invalid {
+				require.NoError(t, err)
+				assert.Equal(t, typeid.MustParse(td.input), tid)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, typeid.ErrValidation), "expected validation error")
+			assert.True(t, tid.IsZero(), "failed parse should return the zero TypeID")
+
+			var mismatch *typeid.PrefixMismatchError
+			require.Equal(t, td.mismatch, errors.As(err, &mismatch))
+			if td.mismatch {
+				assert.Equal(t, typeid.MustParse(td.input).Prefix(), mismatch.Actual)
+				// Allowed is set even when a single prefix was expected
+				assert.ElementsMatch(t, td.prefixes, mismatch.Allowed)
+			}
+		})
+	}
+}
+
+func TestTime(t *testing.T) {
+	testdata := []struct {
+		name  string
+		input string
+		isV7  bool
+		time  time.Time
+	}{
+		{"uuidv7", "prefix_01h455vb4pex5vsknk084sn02q", true, time.UnixMilli(0x01890a5dac96).UTC()},
+		{"nil", "00000000000000000000000000", false, time.Time{}},
+		{"max", "7zzzzzzzzzzzzzzzzzzzzzzzzz", false, time.Time{}},
+		{"uuidv5", "prefix_0123456789abcdefghjkmnpqrs", false, time.Time{}},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			tid := typeid.MustParse(td.input)
+			assert.Equal(t, td.isV7, tid.IsV7())
+			created, ok := tid.Time()
+			assert.Equal(t, td.isV7, ok)
+			assert.Equal(t, td.time, created)
+		})
+	}
+
+	t.Run("uuidv4", func(t *testing.T) {
+		tid, err := typeid.FromUUID("user", uuid.Must(uuid.NewV4()).String())
+		require.NoError(t, err)
+		assert.False(t, tid.IsV7())
+		_, ok := tid.Time()
+		assert.False(t, ok)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		before := time.Now().Truncate(time.Millisecond)
+		tid := typeid.MustGenerate("user")
+		after := time.Now()
+
+		created, ok := tid.Time()
+		require.True(t, ok)
+		assert.Equal(t, time.UTC, created.Location())
+		assert.False(t, created.Before(before), "%v should not be before %v", created, before)
+		assert.False(t, created.After(after), "%v should not be after %v", created, after)
+	})
+}
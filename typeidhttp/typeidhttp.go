@@ -0,0 +1,133 @@
+// Package typeidhttp reads TypeIDs from HTTP requests. Each helper parses a
+// TypeID from part of the request and, if it's missing or invalid, writes an
+// RFC 9457 problem details response so the handler can simply return:
+//
+//	func getUser(w http.ResponseWriter, r *http.Request) {
+//		id, ok := typeidhttp.PathValue(w, r, "id", "user")
+//		if !ok {
+//			return
+//		}
+//		...
+//	}
+package typeidhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// ContentType is the media type of problem details responses.
+const ContentType = "application/problem+json"
+
+// Problem is the RFC 9457 problem details object written when a TypeID is
+// missing or invalid. Besides the standard members, it says where the TypeID
+// was expected and, when known, what was wrong with it.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+
+	// In is where the TypeID was read from: "path", "query" or "header".
+	In string `json:"in"`
+	// Name is the name of the path value, query parameter or header.
+	Name string `json:"name"`
+	// Offset is the byte offset of the offending character, if known.
+	Offset *int `json:"offset,omitempty"`
+	// ExpectedPrefixes lists the allowed prefixes when the prefix didn't match.
+	ExpectedPrefixes []string `json:"expected_prefixes,omitempty"`
+}
+
+// PathValue parses the TypeID in the path wildcard with the given name, as
+// returned by r.PathValue. If prefixes are given, the TypeID must have one of
+// them. It reports whether the TypeID is valid; if not, it has already written a
+// 400 Bad Request problem response to w.
+func PathValue(w http.ResponseWriter, r *http.Request, name string, prefixes ...string) (typeid.TypeID, bool) {
+	return parse(w, "path", name, r.PathValue(name), prefixes)
+}
+
+// Query parses the TypeID in the query parameter with the given name, like
+// PathValue. If the parameter is repeated, the first value is used.
+func Query(w http.ResponseWriter, r *http.Request, name string, prefixes ...string) (typeid.TypeID, bool) {
+	return parse(w, "query", name, r.URL.Query().Get(name), prefixes)
+}
+
+// Header parses the TypeID in the request header with the given name, like
+// PathValue. If the header is repeated, the first value is used.
+func Header(w http.ResponseWriter, r *http.Request, name string, prefixes ...string) (typeid.TypeID, bool) {
+	return parse(w, "header", name, r.Header.Get(name), prefixes)
+}
+
+// parse parses value, read from the named part of the request, and writes a
+// problem response if it's missing or invalid.
+func parse(w http.ResponseWriter, in, name, value string, prefixes []string) (typeid.TypeID, bool) {
+	if value == "" {
+		writeProblem(w, &Problem{
+			Detail: fmt.Sprintf("missing %s %q", describe(in), name),
+			In:     in,
+			Name:   name,
+		})
+		return typeid.TypeID{}, false
+	}
+
+	var tid typeid.TypeID
+	var err error
+	if len(prefixes) == 0 {
+		tid, err = typeid.Parse(value)
+	} else {
+		tid, err = typeid.ParseWithPrefixes(value, prefixes...)
+	}
+	if err != nil {
+		writeProblem(w, newProblem(err, in, name))
+		return typeid.TypeID{}, false
+	}
+	return tid, true
+}
+
+// newProblem returns the problem for a validation error.
+func newProblem(err error, in, name string) *Problem {
+	p := &Problem{
+		Detail: fmt.Sprintf("invalid %s %q: %v", describe(in), name, err),
+		In:     in,
+		Name:   name,
+	}
+	var mismatch *typeid.PrefixMismatchError
+	if errors.As(err, &mismatch) {
+		p.ExpectedPrefixes = mismatch.Allowed
+	}
+	var located interface{ Offset() (int, bool) }
+	if errors.As(err, &located) {
+		if offset, ok := located.Offset(); ok {
+			p.Offset = &offset
+		}
+	}
+	return p
+}
+
+// describe returns a description of where a TypeID was read from, for details.
+func describe(in string) string {
+	switch in {
+	case "path":
+		return "path value"
+	case "query":
+		return "query parameter"
+	default:
+		return "header"
+	}
+}
+
+// writeProblem writes p to w as a 400 Bad Request response.
+func writeProblem(w http.ResponseWriter, p *Problem) {
+	p.Type = "about:blank"
+	p.Status = http.StatusBadRequest
+	p.Title = http.StatusText(p.Status)
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,170 @@
+package typeidhttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+	"go.jetify.com/typeid/v2/typeidhttp"
+)
+
+const testID = "user_01h455vb4pex5vsknk084sn02q"
+
+// newServer returns a handler that reads a user id from the path, the query
+// and a header, and echoes the ids it found.
+func newServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := typeidhttp.PathValue(w, r, "id", "user")
+		if !ok {
+			return
+		}
+		w.Write([]byte(id.String()))
+	})
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		owner, ok := typeidhttp.Query(w, r, "owner", "user", "org")
+		if !ok {
+			return
+		}
+		w.Write([]byte(owner.String()))
+	})
+	mux.HandleFunc("GET /me", func(w http.ResponseWriter, r *http.Request) {
+		session, ok := typeidhttp.Header(w, r, "X-Session-Id")
+		if !ok {
+			return
+		}
+		w.Write([]byte(session.String()))
+	})
+	return mux
+}
+
+// serve sends a GET request for target with the given headers.
+func serve(t *testing.T, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	newServer().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestValid(t *testing.T) {
+	rec := serve(t, "/users/"+testID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testID, rec.Body.String())
+
+	rec = serve(t, "/search?owner=org_01h455vb4pex5vsknk084sn02q&owner=invalid", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "org_01h455vb4pex5vsknk084sn02q", rec.Body.String())
+
+	rec = serve(t, "/me", http.Header{"X-Session-Id": {"session_01h455vb4pex5vsknk084sn02q"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "session_01h455vb4pex5vsknk084sn02q", rec.Body.String())
+}
+
+func TestProblems(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	testdata := []struct {
+		name     string
+		target   string
+		header   http.Header
+		expected typeidhttp.Problem
+	}{
+		{
+			name:   "wrong path prefix",
+			target: "/users/order_01h455vb4pex5vsknk084sn02q",
+			expected: typeidhttp.Problem{
+				Detail:           `invalid path value "id": typeid: expected prefix "user", got "order"`,
+				In:               "path",
+				Name:             "id",
+				ExpectedPrefixes: []string{"user"},
+			},
+		},
+		{
+			name:   "invalid path value",
+			target: "/users/user_01h455vb4pex5vsknk084sn0uq",
+			expected: typeidhttp.Problem{
+				Detail: `invalid path value "id": typeid: invalid suffix encoding: illegal base32 data at offset 24`,
+				In:     "path",
+				Name:   "id",
+				Offset: intPtr(29),
+			},
+		},
+		{
+			name:   "wrong query prefix",
+			target: "/search?owner=" + "team_01h455vb4pex5vsknk084sn02q",
+			expected: typeidhttp.Problem{
+				Detail:           `invalid query parameter "owner": typeid: expected one of prefixes ["user" "org"], got "team"`,
+				In:               "query",
+				Name:             "owner",
+				ExpectedPrefixes: []string{"user", "org"},
+			},
+		},
+		{
+			name:   "missing query parameter",
+			target: "/search?owner=",
+			expected: typeidhttp.Problem{
+				Detail: `missing query parameter "owner"`,
+				In:     "query",
+				Name:   "owner",
+			},
+		},
+		{
+			name:   "invalid header",
+			target: "/me",
+			header: http.Header{"X-Session-Id": {"Session_01h455vb4pex5vsknk084sn02q"}},
+			expected: typeidhttp.Problem{
+				Detail: `invalid header "X-Session-Id": typeid: prefix must contain only [a-z_], found 'S' in "Session"`,
+				In:     "header",
+				Name:   "X-Session-Id",
+				Offset: intPtr(0),
+			},
+		},
+		{
+			name:   "missing header",
+			target: "/me",
+			expected: typeidhttp.Problem{
+				Detail: `missing header "X-Session-Id"`,
+				In:     "header",
+				Name:   "X-Session-Id",
+			},
+		},
+	}
+
+	for _, td := range testdata {
+		t.Run(td.name, func(t *testing.T) {
+			rec := serve(t, td.target, td.header)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, typeidhttp.ContentType, rec.Header().Get("Content-Type"))
+
+			var problem typeidhttp.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			td.expected.Type = "about:blank"
+			td.expected.Title = "Bad Request"
+			td.expected.Status = http.StatusBadRequest
+			assert.Equal(t, td.expected, problem)
+		})
+	}
+}
+
+func TestProblemOmitsUnknownOffset(t *testing.T) {
+	rec := serve(t, "/users/order_01h455vb4pex5vsknk084sn02q", nil)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	assert.NotContains(t, raw, "offset")
+	assert.Contains(t, raw, "detail")
+}
+
+// The helpers accept any valid id when no prefix is given
+func TestAnyPrefix(t *testing.T) {
+	tid := typeid.MustGenerate("")
+	rec := serve(t, "/me", http.Header{"X-Session-Id": {tid.String()}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, tid.String(), rec.Body.String())
+}
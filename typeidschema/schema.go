@@ -0,0 +1,303 @@
+// Package typeidschema generates JSON Schema and OpenAPI 3.1 schemas for
+// TypeIDs, so API documentation can describe their exact format instead of a
+// plain string:
+//
+//	s, err := typeidschema.For("user")
+//	// {"type":"string","pattern":"^user_[0-7][0-9a-hjkmnp-tv-z]{25}$",...}
+//
+// The schemas follow JSON Schema draft 2020-12, which OpenAPI 3.1 uses for its
+// schema objects, so the same Schema works in both.
+package typeidschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.jetify.com/typeid/v2"
+)
+
+// DraftURI identifies the JSON Schema dialect of the generated schemas.
+const DraftURI = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Only the keywords needed to describe TypeIDs and the
+// Go types that contain them are supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            int                `json:"minLength,omitempty"`
+	MaxLength            int                `json:"maxLength,omitempty"`
+	Examples             []string           `json:"examples,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+const (
+	// prefixPattern matches a prefix as checked by typeid's validatePrefix:
+	// lowercase letters and underscores, not starting or ending with one.
+	prefixPattern = "[a-z](?:[a-z_]{0,61}[a-z])?"
+	// suffixPattern matches the 26 character base32 suffix. The first character
+	// is at most 7, as a suffix encodes 128 bits in 130.
+	suffixPattern = "[0-7][0-9a-hjkmnp-tv-z]{25}"
+
+	suffixLen = 26
+	// exampleUUID and exampleSuffix are the UUIDv7 used in examples, and its
+	// encoding.
+	exampleUUID   = "01890a5d-ac96-774b-bcce-b302099a8057"
+	exampleSuffix = "01h455vb4pex5vsknk084sn02q"
+)
+
+// For returns the schema of a TypeID with one of the given prefixes, or with
+// any prefix if none are given. The empty prefix stands for TypeIDs without a
+// prefix. It returns a validation error if a prefix is invalid.
+func For(prefixes ...string) (*Schema, error) {
+	if len(prefixes) == 0 {
+		return &Schema{
+			Type:        "string",
+			Description: "TypeID with any prefix",
+			Pattern:     "^(?:" + prefixPattern + "_)?" + suffixPattern + "$",
+			MinLength:   suffixLen,
+			MaxLength:   63 + 1 + suffixLen,
+			Examples:    []string{exampleSuffix, "user_" + exampleSuffix},
+		}, nil
+	}
+
+	s := &Schema{Type: "string"}
+	alternatives := make([]string, len(prefixes))
+	quoted := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		example, err := example(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if prefix != "" {
+			alternatives[i] = regexp.QuoteMeta(prefix) + "_"
+		}
+		quoted[i] = fmt.Sprintf("%q", prefix)
+		s.Examples = append(s.Examples, example)
+		if i == 0 || len(example) < s.MinLength {
+			s.MinLength = len(example)
+		}
+		s.MaxLength = max(s.MaxLength, len(example))
+	}
+
+	if len(prefixes) == 1 {
+		s.Description = "TypeID with prefix " + quoted[0]
+		s.Pattern = "^" + alternatives[0] + suffixPattern + "$"
+	} else {
+		s.Description = "TypeID with one of the prefixes " + strings.Join(quoted, ", ")
+		s.Pattern = "^(?:" + strings.Join(alternatives, "|") + ")" + suffixPattern + "$"
+	}
+	return s, nil
+}
+
+// example returns an example TypeID with the given prefix, validating it.
+func example(prefix string) (string, error) {
+	tid, err := typeid.FromUUID(prefix, exampleUUID)
+	if err != nil {
+		return "", err
+	}
+	return tid.String(), nil
+}
+
+var (
+	typeIDType        = reflect.TypeFor[typeid.TypeID]()
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// typedID is implemented by typeid.ID for every prefix type.
+type typedID interface {
+	TypeID() typeid.TypeID
+	TypePrefix() string
+}
+
+// FromStruct returns the schema of the JSON encoding of v, which must be a
+// struct or a pointer to one. Field names and optional fields follow the json
+// struct tags, and fields without omitempty or omitzero are required. Pointer
+// fields without them also accept null, which encoding/json writes for nil.
+//
+// typeid.ID fields are described with their prefix type's prefix. TypeID fields
+// accept any prefix, unless the allowed prefixes are listed in a typeid tag:
+//
+//	type Order struct {
+//		ID     OrderID       `json:"id"`
+//		Parent typeid.TypeID `json:"parent,omitzero" typeid:"order,invoice"`
+//	}
+//
+// Other fields get a schema for their JSON type. Interfaces and recursive
+// types are described by the empty schema, which accepts any value.
+func FromStruct(v any) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typeidschema: expected a struct, got %T", v)
+	}
+	w := &walker{visiting: map[reflect.Type]bool{}}
+	return w.schema(t, "")
+}
+
+// walker builds the schemas of Go types.
+type walker struct {
+	visiting map[reflect.Type]bool // Structs being walked, to stop recursion
+}
+
+// schema returns the schema of t. tag is the typeid struct tag of the field
+// being described, if any.
+func (w *walker) schema(t reflect.Type, tag string) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == typeIDType:
+		var prefixes []string
+		if tag != "" {
+			prefixes = strings.Split(tag, ",")
+		}
+		return For(prefixes...)
+	case t.Implements(reflect.TypeFor[typedID]()):
+		return For(reflect.Zero(t).Interface().(typedID).TypePrefix())
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// Encoded as base64 by encoding/json
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := w.schema(t.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := w.schema(t.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if w.visiting[t] {
+			return &Schema{}, nil
+		}
+		w.visiting[t] = true
+		defer delete(w.visiting, t)
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		if err := w.addFields(s, t); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return &Schema{}, nil
+	}
+}
+
+// addFields adds the properties of the struct type t to s. The fields of
+// embedded structs without a json name are promoted, as encoding/json does.
+func (w *walker) addFields(s *Schema, t reflect.Type) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && name == "" && ft.Kind() == reflect.Struct &&
+			ft != typeIDType && !ft.Implements(reflect.TypeFor[typedID]()) {
+			if err := w.addFields(s, ft); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fs, err := w.schema(field.Type, field.Tag.Get("typeid"))
+		if err != nil {
+			return fmt.Errorf("typeidschema: field %s: %w", field.Name, err)
+		}
+		if !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+			if field.Type.Kind() == reflect.Pointer {
+				fs = nullable(fs)
+			}
+		}
+		s.Properties[name] = fs
+	}
+	return nil
+}
+
+// nullable returns a schema that accepts null as well as the values accepted
+// by s.
+func nullable(s *Schema) *Schema {
+	if s.Type == "" {
+		// Already accepts any value
+		return s
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+// hasOption reports whether the comma-separated json tag options contain opt.
+func hasOption(opts, opt string) bool {
+	for o := range strings.SplitSeq(opts, ",") {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONSchema returns s as a standalone JSON Schema document.
+func JSONSchema(s *Schema) ([]byte, error) {
+	doc := *s
+	doc.Schema = DraftURI
+	return json.MarshalIndent(&doc, "", "  ")
+}
+
+// OpenAPIComponents returns an OpenAPI 3.1 fragment that declares the given
+// schemas by name, ready to be merged into a document's components:
+//
+//	{"components": {"schemas": {"UserID": {...}}}}
+//
+// Operations and other schemas refer to them as "#/components/schemas/UserID".
+func OpenAPIComponents(schemas map[string]*Schema) ([]byte, error) {
+	fragment := map[string]any{
+		"components": map[string]any{"schemas": schemas},
+	}
+	return json.MarshalIndent(fragment, "", "  ")
+}
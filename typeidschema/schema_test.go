@@ -0,0 +1,245 @@
+package typeidschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.jetify.com/typeid/v2"
+	"go.jetify.com/typeid/v2/typeidschema"
+)
+
+// readSpec decodes a spec file from the root package's testdata.
+func readSpec(t *testing.T, name string) []struct {
+	Name   string `yaml:"name"`
+	TypeID string `yaml:"typeid"`
+	Prefix string `yaml:"prefix"`
+} {
+	t.Helper()
+	data, err := os.ReadFile("../testdata/" + name)
+	require.NoError(t, err)
+	var examples []struct {
+		Name   string `yaml:"name"`
+		TypeID string `yaml:"typeid"`
+		Prefix string `yaml:"prefix"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &examples))
+	return examples
+}
+
+// matches reports whether s is valid according to the pattern and length
+// constraints of schema.
+func matches(t *testing.T, schema *typeidschema.Schema, s string) bool {
+	t.Helper()
+	re := regexp.MustCompile(schema.Pattern)
+	return re.MatchString(s) && len(s) >= schema.MinLength && len(s) <= schema.MaxLength
+}
+
+// TestPatternMatchesParse checks that the schemas accept exactly the ids that
+// Parse accepts.
+func TestPatternMatchesParse(t *testing.T) {
+	anyPrefix, err := typeidschema.For()
+	require.NoError(t, err)
+
+	for _, ex := range readSpec(t, "valid.yml") {
+		assert.True(t, matches(t, anyPrefix, ex.TypeID), ex.Name)
+
+		schema, err := typeidschema.For(ex.Prefix)
+		require.NoError(t, err)
+		assert.True(t, matches(t, schema, ex.TypeID), ex.Name)
+
+		other, err := typeidschema.For("other")
+		require.NoError(t, err)
+		assert.False(t, matches(t, other, ex.TypeID), ex.Name)
+	}
+
+	for _, ex := range readSpec(t, "invalid.yml") {
+		assert.False(t, matches(t, anyPrefix, ex.TypeID), ex.Name)
+	}
+
+	// Generated ids with prefixes of every length
+	for n := 1; n <= 63; n++ {
+		prefix := "a"
+		for len(prefix) < n {
+			prefix = "_" + prefix
+			if len(prefix) < n {
+				prefix = "b" + prefix
+			}
+		}
+		tid, err := typeid.Generate(prefix)
+		if err != nil {
+			// Prefixes starting with an underscore are invalid
+			assert.False(t, matches(t, anyPrefix, prefix+"_"+typeid.ZeroSuffix), prefix)
+			continue
+		}
+		assert.True(t, matches(t, anyPrefix, tid.String()), prefix)
+
+		schema, err := typeidschema.For(prefix)
+		require.NoError(t, err)
+		assert.True(t, matches(t, schema, tid.String()), prefix)
+	}
+}
+
+func TestFor(t *testing.T) {
+	schema, err := typeidschema.For("user")
+	require.NoError(t, err)
+	assert.Equal(t, &typeidschema.Schema{
+		Type:        "string",
+		Description: `TypeID with prefix "user"`,
+		Pattern:     "^user_[0-7][0-9a-hjkmnp-tv-z]{25}$",
+		MinLength:   31,
+		MaxLength:   31,
+		Examples:    []string{"user_01h455vb4pex5vsknk084sn02q"},
+	}, schema)
+
+	schema, err = typeidschema.For("user", "")
+	require.NoError(t, err)
+	assert.Equal(t, &typeidschema.Schema{
+		Type:        "string",
+		Description: `TypeID with one of the prefixes "user", ""`,
+		Pattern:     "^(?:user_|)[0-7][0-9a-hjkmnp-tv-z]{25}$",
+		MinLength:   26,
+		MaxLength:   31,
+		Examples:    []string{"user_01h455vb4pex5vsknk084sn02q", "01h455vb4pex5vsknk084sn02q"},
+	}, schema)
+	assert.True(t, matches(t, schema, "01h455vb4pex5vsknk084sn02q"))
+	assert.True(t, matches(t, schema, "user_01h455vb4pex5vsknk084sn02q"))
+	assert.False(t, matches(t, schema, "admin_01h455vb4pex5vsknk084sn02q"))
+
+	// The examples must be valid
+	for _, example := range schema.Examples {
+		_, err := typeid.ParseWithPrefixes(example, "user", "")
+		assert.NoError(t, err)
+	}
+
+	_, err = typeidschema.For("User")
+	assert.True(t, errors.Is(err, typeid.ErrValidation))
+}
+
+type userPrefix struct{}
+
+func (userPrefix) Prefix() string { return "user" }
+
+type UserID = typeid.ID[userPrefix]
+
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy UserID    `json:"created_by"`
+}
+
+type Order struct {
+	Audit
+	ID       typeid.TypeID            `json:"id" typeid:"order"`
+	Owner    *UserID                  `json:"owner,omitempty"`
+	Related  []typeid.TypeID          `json:"related,omitzero" typeid:"order,invoice"`
+	Labels   map[string]string        `json:"labels,omitempty"`
+	Total    float64                  `json:"total"`
+	Items    int                      `json:"item_count"`
+	Paid     bool                     `json:"paid"`
+	Parent   *Order                   `json:"parent,omitempty"`
+	Extra    any                      `json:"extra,omitempty"`
+	Checksum []byte                   `json:"checksum,omitempty"`
+	Notes    string                   // No tag
+	Internal string                   `json:"-"`
+	secret   string                   //nolint:unused
+	Lookup   map[string]typeid.TypeID `json:"lookup,omitempty"`
+	Approver *UserID                  `json:"approver"`
+	Previous *Order                   `json:"previous"`
+}
+
+func TestFromStruct(t *testing.T) {
+	schema, err := typeidschema.FromStruct(&Order{})
+	require.NoError(t, err)
+
+	orderID, _ := typeidschema.For("order")
+	userID, _ := typeidschema.For("user")
+	related, _ := typeidschema.For("order", "invoice")
+	anyID, _ := typeidschema.For()
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, map[string]*typeidschema.Schema{
+		"created_at": {Type: "string", Format: "date-time"},
+		"created_by": userID,
+		"id":         orderID,
+		"owner":      userID,
+		"related":    {Type: "array", Items: related},
+		"labels":     {Type: "object", AdditionalProperties: &typeidschema.Schema{Type: "string"}},
+		"total":      {Type: "number"},
+		"item_count": {Type: "integer"},
+		"paid":       {Type: "boolean"},
+		"parent":     {},
+		"extra":      {},
+		"checksum":   {Type: "string", Format: "byte"},
+		"Notes":      {Type: "string"},
+		"lookup":     {Type: "object", AdditionalProperties: anyID},
+		// encoding/json writes null for nil pointers without omitempty
+		"approver": {AnyOf: []*typeidschema.Schema{userID, {Type: "null"}}},
+		"previous": {},
+	}, schema.Properties)
+	assert.Equal(t, []string{"created_at", "created_by", "id", "total", "item_count", "paid", "Notes", "approver", "previous"}, schema.Required)
+}
+
+func TestFromStructErrors(t *testing.T) {
+	_, err := typeidschema.FromStruct("user")
+	assert.EqualError(t, err, "typeidschema: expected a struct, got string")
+
+	_, err = typeidschema.FromStruct(struct {
+		ID typeid.TypeID `typeid:"Order"`
+	}{})
+	assert.True(t, errors.Is(err, typeid.ErrValidation))
+	assert.ErrorContains(t, err, "typeidschema: field ID: ")
+}
+
+func TestDocuments(t *testing.T) {
+	schema, err := typeidschema.For("user")
+	require.NoError(t, err)
+
+	doc, err := typeidschema.JSONSchema(schema)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(doc, &decoded))
+	assert.Equal(t, typeidschema.DraftURI, decoded["$schema"])
+	assert.Empty(t, schema.Schema, "JSONSchema must not modify its argument")
+
+	fragment, err := typeidschema.OpenAPIComponents(map[string]*typeidschema.Schema{"UserID": schema})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(fragment, &decoded))
+	assert.Equal(t, "^user_[0-7][0-9a-hjkmnp-tv-z]{25}$",
+		decoded["components"].(map[string]any)["schemas"].(map[string]any)["UserID"].(map[string]any)["pattern"])
+}
+
+func ExampleOpenAPIComponents() {
+	userID, err := typeidschema.For("user")
+	if err != nil {
+		panic(err)
+	}
+	fragment, err := typeidschema.OpenAPIComponents(map[string]*typeidschema.Schema{"UserID": userID})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(fragment))
+	// Output:
+	// {
+	//   "components": {
+	//     "schemas": {
+	//       "UserID": {
+	//         "type": "string",
+	//         "description": "TypeID with prefix \"user\"",
+	//         "pattern": "^user_[0-7][0-9a-hjkmnp-tv-z]{25}$",
+	//         "minLength": 31,
+	//         "maxLength": 31,
+	//         "examples": [
+	//           "user_01h455vb4pex5vsknk084sn02q"
+	//         ]
+	//       }
+	//     }
+	//   }
+	// }
+}
@@ -2,59 +2,118 @@ package typeid
 
 import (
 	"fmt"
+	"slices"
+	"unicode/utf8"
 
 	"go.jetify.com/typeid/v2/base32"
 )
 
-func validatePrefix(prefix string) error {
-	if len(prefix) > 63 {
-		return &validationError{
-			Message: fmt.Sprintf("prefix length must be <= 63, got %d for %q", len(prefix), prefix),
-		}
-	}
+// ruleViolation identifies the rule of the spec that a prefix or suffix breaks.
+type ruleViolation uint8
 
-	if len(prefix) > 0 && prefix[0] == '_' {
-		return &validationError{
-			Message: fmt.Sprintf("prefix cannot start with underscore, got %q", prefix),
-		}
-	}
+const (
+	noViolation ruleViolation = iota
+	prefixTooLong
+	prefixLeadingUnderscore
+	prefixTrailingUnderscore
+	prefixInvalidChar
+	suffixWrongLength
+	suffixFirstCharTooLarge
+	suffixInvalidEncoding
+)
 
-	if len(prefix) > 0 && prefix[len(prefix)-1] == '_' {
-		return &validationError{
-			Message: fmt.Sprintf("prefix cannot end with underscore, got %q", prefix),
-		}
+// checkPrefixRules checks prefix against the spec without allocating, so that
+// it can be applied to byte slices as well as strings. It returns the rule that
+// is broken, if any, and the 0-based offset of the offending character.
+func checkPrefixRules[T string | []byte](prefix T) (ruleViolation, int) {
+	switch {
+	case len(prefix) > 63:
+		return prefixTooLong, 63
+	case len(prefix) > 0 && prefix[0] == '_':
+		return prefixLeadingUnderscore, 0
+	case len(prefix) > 0 && prefix[len(prefix)-1] == '_':
+		return prefixTrailingUnderscore, len(prefix) - 1
 	}
-
 	// Ensure that the prefix only has lowercase ASCII characters
-	for _, c := range prefix {
-		if (c < 'a' || c > 'z') && c != '_' {
-			return &validationError{
-				Message: fmt.Sprintf("prefix must contain only [a-z_], found %q in %q", c, prefix),
-			}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return prefixInvalidChar, i
 		}
 	}
-
-	return nil
+	return noViolation, 0
 }
 
-func validateSuffix(suffix string) error {
+// checkSuffixRules checks suffix against the spec without allocating, like
+// checkPrefixRules.
+func checkSuffixRules[T string | []byte](suffix T) (ruleViolation, int) {
 	if len(suffix) != 26 {
-		return &validationError{
-			Message: fmt.Sprintf("suffix length must be 26, got %d", len(suffix)),
-		}
+		return suffixWrongLength, min(len(suffix), 26)
 	}
-
 	if suffix[0] > '7' {
-		return &validationError{
-			Message: fmt.Sprintf("suffix must start with 0-7, got %q", suffix[0]),
-		}
+		return suffixFirstCharTooLarge, 0
 	}
-	// Validate the suffix using zero-allocation validation
-	if err := base32.ValidateString(suffix); err != nil {
-		return &validationError{
-			Message: "invalid suffix encoding",
-			Cause:   err,
-		}
+	if err := base32.ValidateBytes([]byte(suffix)); err != nil {
+		corrupt, _ := err.(base32.CorruptInputError)
+		return suffixInvalidEncoding, int(corrupt)
+	}
+	return noViolation, 0
+}
+
+func validatePrefix(prefix string) error {
+	var message string
+	violation, offset := checkPrefixRules(prefix)
+	switch violation {
+	case noViolation:
+		return nil
+	case prefixTooLong:
+		message = fmt.Sprintf("prefix length must be <= 63, got %d for %q", len(prefix), prefix)
+	case prefixLeadingUnderscore:
+		message = fmt.Sprintf("prefix cannot start with underscore, got %q", prefix)
+	case prefixTrailingUnderscore:
+		message = fmt.Sprintf("prefix cannot end with underscore, got %q", prefix)
+	default:
+		c, _ := utf8.DecodeRuneInString(prefix[offset:])
+		message = fmt.Sprintf("prefix must contain only [a-z_], found %q in %q", c, prefix)
+	}
+	return &validationError{Message: message, pos: offset + 1}
+}
+
+// validateSuffix validates the suffix of a TypeID. start is the offset of the
+// suffix within the string being parsed, used to report error positions.
+func validateSuffix(suffix string, start int) error {
+	violation, offset := checkSuffixRules(suffix)
+	if violation == noViolation {
+		return nil
+	}
+	verr := &validationError{pos: start + offset + 1}
+	switch violation {
+	case suffixWrongLength:
+		verr.Message = fmt.Sprintf("suffix length must be 26, got %d", len(suffix))
+	case suffixFirstCharTooLarge:
+		verr.Message = fmt.Sprintf("suffix must start with 0-7, got %q", suffix[0])
+	default:
+		verr.Message = "invalid suffix encoding"
+		verr.Cause = base32.CorruptInputError(offset)
+	}
+	return verr
+}
+
+// checkPrefix returns a PrefixMismatchError if tid does not have the expected prefix.
+func checkPrefix(tid TypeID, expected string) error {
+	if actual := tid.Prefix(); actual != expected {
+		return &PrefixMismatchError{Expected: expected, Allowed: []string{expected}, Actual: actual}
 	}
 	return nil
 }
+
+// checkPrefixes returns a PrefixMismatchError if tid's prefix is not one of allowed.
+func checkPrefixes(tid TypeID, allowed []string) error {
+	if len(allowed) == 1 {
+		return checkPrefix(tid, allowed[0])
+	}
+	actual := tid.Prefix()
+	if slices.Contains(allowed, actual) {
+		return nil
+	}
+	return &PrefixMismatchError{Allowed: append([]string{}, allowed...), Actual: actual}
+}